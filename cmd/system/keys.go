@@ -0,0 +1,60 @@
+package system
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/spf13/cobra"
+)
+
+func NewKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "PASETO key ring management",
+	}
+
+	cmd.AddCommand(NewGenerateKeyCommand())
+
+	return cmd
+}
+
+func NewGenerateKeyCommand() *cobra.Command {
+	var (
+		keyID   string
+		purpose string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a new PASETO key for the auth key ring",
+		Long: `Generate a new random PASETO v4 key (or Ed25519 key pair for public
+mode) and print it as a config snippet.
+Add it to auth.keys, then point auth.active_key_id at it to start issuing
+tokens with it. Keep the previous key (not retired) until its tokens expire.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyID == "" {
+				keyID = "k" + time.Now().UTC().Format("20060102150405")
+			}
+
+			switch purpose {
+			case auth.TokenModeLocal:
+				fmt.Printf("    - id: %q\n", keyID)
+				fmt.Printf("      key: %q\n", auth.GenerateKey())
+			case auth.TokenModePublic:
+				secret, public := auth.GenerateKeyPair()
+				fmt.Printf("    - id: %q\n", keyID)
+				fmt.Printf("      key: %q\n", secret)
+				fmt.Printf("      public_key: %q\n", public)
+			default:
+				return fmt.Errorf("unknown key purpose %q, expected %q or %q", purpose, auth.TokenModeLocal, auth.TokenModePublic)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keyID, "id", "", "Key ID (defaults to a timestamp based ID)")
+	cmd.Flags().StringVar(&purpose, "purpose", auth.TokenModeLocal, "Key purpose matching auth.token_mode: local or public")
+
+	return cmd
+}
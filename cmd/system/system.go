@@ -9,6 +9,7 @@ func NewSystemCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(NewMigrateCommand())
+	cmd.AddCommand(NewKeysCommand())
 
 	return cmd
 }
@@ -32,7 +32,62 @@ var DefaultConfig = Config{
 		LogLevel: "info",
 	},
 	Auth: AuthConfig{
-		PasetoKey:       "your-32-character-secret-key!",
-		TokenExpiration: 24, // 24 hours
+		TokenMode:              "local",
+		AccessTokenExpiration:  15,  // 15 minutes
+		RefreshTokenExpiration: 720, // 30 days
+		MFA: MFAConfig{
+			Issuer:             "ChatApp",
+			PendingTokenTTL:    5, // 5 minutes
+			MaxAttempts:        5,
+			RecoveryCodesCount: 10,
+		},
+		LoginProtection: LoginProtectionConfig{
+			Store:           "memory",
+			MaxAttempts:     5,
+			IPMaxAttempts:   20,
+			BaseDelay:       1,  // 1 second
+			MaxDelay:        30, // 30 seconds
+			LockoutDuration: 15, // 15 minutes
+			Window:          15, // 15 minutes
+		},
+		PasswordPolicy: PasswordPolicyConfig{
+			MinLength: 8,
+			MaxLength: 128,
+		},
+		Argon2: Argon2Config{
+			Memory:      19 * 1024, // 19 MiB
+			Iterations:  2,
+			Parallelism: 1,
+			SaltLength:  16,
+			KeyLength:   32,
+		},
+		OIDC: OIDCConfig{
+			StateTTL:     10, // 10 minutes
+			ReauthWindow: 5,  // 5 minutes
+		},
+	},
+	Mail: MailConfig{
+		Driver:               "log",
+		From:                 "ChatApp <no-reply@localhost>",
+		SMTPPort:             587,
+		FileDir:              "./tmp/mail",
+		BaseURL:              "http://localhost:3000",
+		VerificationTokenTTL: 48, // 48 hours
+		ResetTokenTTL:        30, // 30 minutes
+	},
+	WebSocket: WebSocketConfig{
+		Broker:           "memory",
+		TicketStore:      "memory",
+		TicketTTL:        30, // 30 seconds
+		SendBuffer:       256,
+		PingInterval:     25, // 25 seconds
+		PongTimeout:      60, // 60 seconds
+		WriteTimeout:     10, // 10 seconds
+		IdleTimeout:      30, // 30 minutes
+		MaxMessageSize:   64 * 1024,
+		EventRetention:   72, // 3 days
+		ReplayLimit:      1000,
+		TypingTimeout:    6, // 6 seconds
+		PresenceDebounce: 5, // 5 seconds
 	},
 }
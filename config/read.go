@@ -30,19 +30,27 @@ func ReadConfig(configPath string) (*Config, error) {
 	return &config, nil
 }
 
+// zeroAllowed lists the settings for which 0 is a meaningful value. They only
+// get their default when the config file does not set them.
+var zeroAllowed = map[string]bool{
+	"auth.login_protection.max_attempts":    true, // 0 disables the username lockout
+	"auth.login_protection.ip_max_attempts": true, // 0 disables the IP lockout
+}
+
 // mergeWithDefaults automatically applies default values from DefaultConfig to the given config.
 func mergeWithDefaults(config *Config) {
-	applyDefaults(reflect.ValueOf(config), reflect.ValueOf(&DefaultConfig).Elem())
+	applyDefaults(reflect.ValueOf(config), reflect.ValueOf(&DefaultConfig).Elem(), "")
 }
 
-func applyDefaults(target, defaults reflect.Value) {
+func applyDefaults(target, defaults reflect.Value, prefix string) {
 	for i := 0; i < target.Elem().NumField(); i++ {
 		targetField := target.Elem().Field(i)
 		defaultField := defaults.Field(i)
+		key := prefix + target.Elem().Type().Field(i).Tag.Get("mapstructure")
 
 		if targetField.Kind() == reflect.Struct {
-			applyDefaults(targetField.Addr(), defaultField)
-		} else if isZeroValue(targetField) {
+			applyDefaults(targetField.Addr(), defaultField, key+".")
+		} else if isZeroValue(targetField) && !(zeroAllowed[key] && viper.IsSet(key)) {
 			targetField.Set(defaultField)
 		}
 	}
@@ -7,12 +7,139 @@ type Config struct {
 	Redis      RedisConfig      `mapstructure:"redis"`
 	Logger     LoggerConfig     `mapstructure:"logger"`
 	Auth       AuthConfig       `mapstructure:"auth"`
+	Mail       MailConfig       `mapstructure:"mail"`
+	WebSocket  WebSocketConfig  `mapstructure:"websocket"`
+}
+
+// WebSocketConfig represents the real-time connection configuration structure.
+type WebSocketConfig struct {
+	// Broker connects the hubs of several instances: "memory" (single
+	// instance), "postgres" (LISTEN/NOTIFY) or "redis" (pub/sub)
+	Broker      string `mapstructure:"broker"`
+	TicketStore string `mapstructure:"ticket_store"` // "memory" or "redis"
+	TicketTTL   int    `mapstructure:"ticket_ttl"`   // in seconds
+	SendBuffer  int    `mapstructure:"send_buffer"`  // outgoing messages queued per connection
+	// Heartbeats: the server pings every PingInterval and drops connections
+	// that did not answer within PongTimeout.
+	PingInterval   int   `mapstructure:"ping_interval"`    // in seconds
+	PongTimeout    int   `mapstructure:"pong_timeout"`     // in seconds, must be greater than ping_interval
+	WriteTimeout   int   `mapstructure:"write_timeout"`    // in seconds
+	IdleTimeout    int   `mapstructure:"idle_timeout"`     // in minutes without any message from the client
+	MaxMessageSize int64 `mapstructure:"max_message_size"` // in bytes
+	// Events are logged per user so clients can reconnect with ?since=<seq>
+	EventRetention int `mapstructure:"event_retention"` // in hours
+	ReplayLimit    int `mapstructure:"replay_limit"`    // most events replayed before a resync is required
+	// TypingTimeout is how long a typing.start lasts without being repeated.
+	// Repeated starts are passed on at most every half of it.
+	TypingTimeout int `mapstructure:"typing_timeout"` // in seconds
+	// PresenceDebounce is how long a presence change must last before it is
+	// announced, so reloads and short network drops are not.
+	PresenceDebounce int `mapstructure:"presence_debounce"` // in seconds
+	// AllowQueryToken keeps accepting access tokens in ?token= so old clients
+	// keep working during migration. Tokens in URLs end up in proxy logs.
+	AllowQueryToken bool `mapstructure:"allow_query_token"`
+}
+
+// MailConfig represents the outgoing email configuration structure.
+type MailConfig struct {
+	Driver               string `mapstructure:"driver"` // "smtp", "file" or "log"
+	From                 string `mapstructure:"from"`
+	SMTPHost             string `mapstructure:"smtp_host"`
+	SMTPPort             uint   `mapstructure:"smtp_port"`
+	SMTPUser             string `mapstructure:"smtp_user"`
+	SMTPPass             string `mapstructure:"smtp_pass"`
+	FileDir              string `mapstructure:"file_dir"`               // used by the file driver
+	BaseURL              string `mapstructure:"base_url"`               // frontend URL used in email links
+	VerificationTokenTTL int    `mapstructure:"verification_token_ttl"` // in hours
+	ResetTokenTTL        int    `mapstructure:"reset_token_ttl"`        // in minutes
 }
 
 // AuthConfig represents the authentication configuration structure.
 type AuthConfig struct {
-	PasetoKey       string `mapstructure:"paseto_key"`
-	TokenExpiration int    `mapstructure:"token_expiration"` // in hours
+	TokenMode              string                `mapstructure:"token_mode"` // "local" (v4.local) or "public" (v4.public)
+	ActiveKeyID            string                `mapstructure:"active_key_id"`
+	Keys                   []PasetoKeyConfig     `mapstructure:"keys"`
+	AccessTokenExpiration  int                   `mapstructure:"access_token_expiration"`  // in minutes
+	RefreshTokenExpiration int                   `mapstructure:"refresh_token_expiration"` // in hours
+	MFA                    MFAConfig             `mapstructure:"mfa"`
+	LoginProtection        LoginProtectionConfig `mapstructure:"login_protection"`
+	PasswordPolicy         PasswordPolicyConfig  `mapstructure:"password_policy"`
+	Argon2                 Argon2Config          `mapstructure:"argon2"`
+	OIDC                   OIDCConfig            `mapstructure:"oidc"`
+}
+
+// OIDCConfig represents the single sign-on configuration structure.
+type OIDCConfig struct {
+	StateTTL int `mapstructure:"state_ttl"` // in minutes
+	// FrontendRedirectURL receives the tokens in the URL fragment after a
+	// login. When empty the callback responds with JSON.
+	FrontendRedirectURL string `mapstructure:"frontend_redirect_url"`
+	// ReauthWindow is how recent the login of a user without a password must
+	// be to change the password or delete the account without a 2FA code.
+	ReauthWindow int                  `mapstructure:"reauth_window"` // in minutes
+	Providers    []OIDCProviderConfig `mapstructure:"providers"`
+}
+
+// OIDCProviderConfig configures one OpenID Connect identity provider.
+type OIDCProviderConfig struct {
+	Name                 string   `mapstructure:"name"` // used in URLs and stored on identities
+	DisplayName          string   `mapstructure:"display_name"`
+	IssuerURL            string   `mapstructure:"issuer_url"`
+	ClientID             string   `mapstructure:"client_id"`
+	ClientSecret         string   `mapstructure:"client_secret"`
+	RedirectURL          string   `mapstructure:"redirect_url"` // .../api/v1/auth/oidc/<name>/callback
+	Scopes               []string `mapstructure:"scopes"`       // "openid" is always requested
+	DisableAutoProvision bool     `mapstructure:"disable_auto_provision"`
+}
+
+// PasswordPolicyConfig holds the rules new passwords must follow. Lengths are
+// counted in characters.
+type PasswordPolicyConfig struct {
+	MinLength     int  `mapstructure:"min_length"`
+	MaxLength     int  `mapstructure:"max_length"`
+	RequireUpper  bool `mapstructure:"require_upper"`
+	RequireLower  bool `mapstructure:"require_lower"`
+	RequireDigit  bool `mapstructure:"require_digit"`
+	RequireSymbol bool `mapstructure:"require_symbol"`
+	AllowCommon   bool `mapstructure:"allow_common"`   // skip the bundled common-password denylist
+	AllowUsername bool `mapstructure:"allow_username"` // allow the username inside the password
+}
+
+// Argon2Config holds the argon2id parameters for new password hashes. Hashes
+// with other parameters are upgraded on the next successful login.
+type Argon2Config struct {
+	Memory      uint32 `mapstructure:"memory"` // in KiB
+	Iterations  uint32 `mapstructure:"iterations"`
+	Parallelism uint8  `mapstructure:"parallelism"`
+	SaltLength  uint32 `mapstructure:"salt_length"`
+	KeyLength   uint32 `mapstructure:"key_length"`
+}
+
+// LoginProtectionConfig represents the brute-force protection configuration structure.
+type LoginProtectionConfig struct {
+	Store           string `mapstructure:"store"`            // "memory" or "redis"
+	MaxAttempts     int    `mapstructure:"max_attempts"`     // failures per username before lockout, 0 disables it
+	IPMaxAttempts   int    `mapstructure:"ip_max_attempts"`  // failures per IP before lockout, 0 disables it
+	BaseDelay       int    `mapstructure:"base_delay"`       // in seconds, doubled after every failure
+	MaxDelay        int    `mapstructure:"max_delay"`        // in seconds
+	LockoutDuration int    `mapstructure:"lockout_duration"` // in minutes
+	Window          int    `mapstructure:"window"`           // in minutes, failures are forgotten after this
+}
+
+// MFAConfig represents the two-factor authentication configuration structure.
+type MFAConfig struct {
+	Issuer             string `mapstructure:"issuer"`               // shown in authenticator apps
+	PendingTokenTTL    int    `mapstructure:"pending_token_ttl"`    // in minutes
+	MaxAttempts        int    `mapstructure:"max_attempts"`         // codes accepted per pending token
+	RecoveryCodesCount int    `mapstructure:"recovery_codes_count"` // codes issued per generation
+}
+
+// PasetoKeyConfig represents one key of the PASETO key ring.
+type PasetoKeyConfig struct {
+	ID        string `mapstructure:"id"`
+	Key       string `mapstructure:"key"`        // hex-encoded symmetric key (local) or Ed25519 secret key (public)
+	PublicKey string `mapstructure:"public_key"` // hex-encoded Ed25519 public key, enough for verify-only keys in public mode
+	Retired   bool   `mapstructure:"retired"`    // retired keys are no longer accepted for verification
 }
 
 // ServerConfig represents the general server configuration structure.
@@ -53,4 +180,5 @@ type RedisConfig struct {
 	Host     string `mapstructure:"host"`
 	Port     uint   `mapstructure:"port"`
 	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
 }
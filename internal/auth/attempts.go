@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AttemptState is the failed login history of one key (a username or an IP).
+type AttemptState struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// AttemptStore persists failed login attempts. Implementations must be safe
+// for concurrent use; the Redis implementation shares state across instances.
+type AttemptStore interface {
+	// Get returns the state of the key, or the zero state if there is none.
+	Get(ctx context.Context, key string) (AttemptState, error)
+	// RecordFailure increments the failure counter of the key. The state is
+	// forgotten once no failure happened for the given window.
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (AttemptState, error)
+	// Lock locks the key until the given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset forgets the key.
+	Reset(ctx context.Context, key string) error
+}
+
+type memoryAttempt struct {
+	state     AttemptState
+	expiresAt time.Time
+}
+
+// MemoryAttemptStore keeps attempts in process memory. It is suitable for a
+// single instance only.
+type MemoryAttemptStore struct {
+	mu        sync.Mutex
+	attempts  map[string]*memoryAttempt
+	clock     Clock
+	lastSweep time.Time
+}
+
+func NewMemoryAttemptStore(clock Clock) *MemoryAttemptStore {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &MemoryAttemptStore{
+		attempts: make(map[string]*memoryAttempt),
+		clock:    clock,
+	}
+}
+
+func (s *MemoryAttemptStore) Get(_ context.Context, key string) (AttemptState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a := s.lookup(key); a != nil {
+		return a.state, nil
+	}
+	return AttemptState{}, nil
+}
+
+func (s *MemoryAttemptStore) RecordFailure(_ context.Context, key string, at time.Time, window time.Duration) (AttemptState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.lookup(key)
+	if a == nil {
+		s.sweep()
+		a = &memoryAttempt{}
+		s.attempts[key] = a
+	}
+
+	a.state.Failures++
+	a.state.LastFailure = at
+	if expiresAt := at.Add(window); expiresAt.After(a.expiresAt) {
+		a.expiresAt = expiresAt
+	}
+
+	return a.state, nil
+}
+
+func (s *MemoryAttemptStore) Lock(_ context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.lookup(key)
+	if a == nil {
+		a = &memoryAttempt{}
+		s.attempts[key] = a
+	}
+
+	a.state.LockedUntil = until
+	if until.After(a.expiresAt) {
+		a.expiresAt = until
+	}
+
+	return nil
+}
+
+func (s *MemoryAttemptStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// lookup returns the live entry of the key, dropping it if it expired.
+// The caller must hold the lock.
+func (s *MemoryAttemptStore) lookup(key string) *memoryAttempt {
+	a, ok := s.attempts[key]
+	if !ok {
+		return nil
+	}
+	if !s.clock.Now().Before(a.expiresAt) {
+		delete(s.attempts, key)
+		return nil
+	}
+	return a
+}
+
+// sweep drops expired entries, at most once a minute, so keys that are never
+// touched again do not accumulate. The caller must hold the lock.
+func (s *MemoryAttemptStore) sweep() {
+	now := s.clock.Now()
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, a := range s.attempts {
+		if !now.Before(a.expiresAt) {
+			delete(s.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisAttemptPrefix = "chatapp:login_attempts:"
+
+// recordFailureScript increments the counter and extends the key TTL, but never
+// shortens it below an active lock.
+var recordFailureScript = redis.NewScript(`
+local failures = redis.call("HINCRBY", KEYS[1], "failures", 1)
+redis.call("HSET", KEYS[1], "last_failure", ARGV[1])
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < tonumber(ARGV[2]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return redis.call("HMGET", KEYS[1], "failures", "last_failure", "locked_until")
+`)
+
+// lockScript records the lock and makes sure the key lives at least until it ends.
+var lockScript = redis.NewScript(`
+redis.call("HSET", KEYS[1], "locked_until", ARGV[1])
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < tonumber(ARGV[2]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// RedisAttemptStore keeps attempts in Redis so every instance shares them.
+type RedisAttemptStore struct {
+	client *redis.Client
+	clock  Clock
+}
+
+func NewRedisAttemptStore(client *redis.Client, clock Clock) *RedisAttemptStore {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &RedisAttemptStore{
+		client: client,
+		clock:  clock,
+	}
+}
+
+func (s *RedisAttemptStore) Get(ctx context.Context, key string) (AttemptState, error) {
+	values, err := s.client.HMGet(ctx, redisAttemptPrefix+key, "failures", "last_failure", "locked_until").Result()
+	if err != nil {
+		return AttemptState{}, fmt.Errorf("failed to get login attempts: %w", err)
+	}
+	return parseAttemptState(values), nil
+}
+
+func (s *RedisAttemptStore) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (AttemptState, error) {
+	values, err := recordFailureScript.Run(ctx, s.client,
+		[]string{redisAttemptPrefix + key},
+		at.UnixMilli(), window.Milliseconds(),
+	).Slice()
+	if err != nil {
+		return AttemptState{}, fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	return parseAttemptState(values), nil
+}
+
+func (s *RedisAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := until.Sub(s.clock.Now())
+	if ttl <= 0 {
+		return nil
+	}
+
+	err := lockScript.Run(ctx, s.client,
+		[]string{redisAttemptPrefix + key},
+		until.UnixMilli(), ttl.Milliseconds(),
+	).Err()
+	if err != nil {
+		return fmt.Errorf("failed to lock login key: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisAttemptStore) Reset(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, redisAttemptPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
+
+// parseAttemptState converts [failures, last_failure, locked_until] as
+// returned by HMGET (nil for missing fields) into a state.
+func parseAttemptState(values []interface{}) AttemptState {
+	var state AttemptState
+	if len(values) != 3 {
+		return state
+	}
+
+	state.Failures = int(redisInt(values[0]))
+	if ms := redisInt(values[1]); ms > 0 {
+		state.LastFailure = time.UnixMilli(ms)
+	}
+	if ms := redisInt(values[2]); ms > 0 {
+		state.LockedUntil = time.UnixMilli(ms)
+	}
+	return state
+}
+
+func redisInt(value interface{}) int64 {
+	switch v := value.(type) {
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	case int64:
+		return v
+	default:
+		return 0
+	}
+}
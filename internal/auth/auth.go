@@ -6,55 +6,126 @@ import (
 	"time"
 
 	"aidanwoods.dev/go-paseto"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
 )
 
+// refreshImplicit is bound to refresh tokens as the PASETO implicit assertion,
+// so a refresh token can never be accepted where an access token is expected
+// and vice versa.
+var refreshImplicit = []byte("refresh")
+
+// mfaImplicit is bound to "MFA pending" tokens, which only prove that the
+// password step of a two-step login succeeded.
+var mfaImplicit = []byte("mfa")
+
+// oidcImplicit is bound to the state of an OIDC login, which is kept in a
+// cookie between the redirect to the provider and the callback.
+var oidcImplicit = []byte("oidc")
+
+// MFAPayload identifies a user who passed the password step of a login and
+// still has to present a second factor. TokenID identifies the token so it
+// can be used only once.
+type MFAPayload struct {
+	UserID   int       `json:"user_id"`
+	TokenID  string    `json:"token_id"`
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+// OIDCState is the state of an OIDC authorization-code flow. LinkUserID is set
+// when an authenticated user links a new identity instead of logging in.
+type OIDCState struct {
+	Provider     string    `json:"provider"`
+	State        string    `json:"state"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	LinkUserID   int       `json:"link_user_id,omitempty"`
+	ExpireAt     time.Time `json:"expire_at"`
+}
+
 type Service struct {
-	symmetricKey    paseto.V4SymmetricKey
-	tokenExpiration time.Duration
+	keyRing           *KeyRing
+	hasher            *PasswordHasher
+	policy            *PasswordPolicy
+	accessExpiration  time.Duration
+	refreshExpiration time.Duration
 }
 
 type TokenPayload struct {
-	UserID   int       `json:"user_id"`
-	Username string    `json:"username"`
-	IssuedAt time.Time `json:"issued_at"`
-	ExpireAt time.Time `json:"expire_at"`
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	SessionID int       `json:"session_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpireAt  time.Time `json:"expire_at"`
 }
 
-func NewAuthService(pasetoKey string, expirationHours int) *Service {
-	// Create a V4 symmetric key from the provided key string
-	// For production, use paseto.NewV4SymmetricKey() to generate a secure key
-	key, err := paseto.V4SymmetricKeyFromBytes([]byte(pasetoKey))
+// RefreshPayload identifies a session and the generation of its refresh token.
+// Every rotation bumps the generation, which is what makes reuse detectable.
+type RefreshPayload struct {
+	SessionID  int       `json:"session_id"`
+	Generation int       `json:"generation"`
+	ExpireAt   time.Time `json:"expire_at"`
+}
+
+func NewAuthService(cfg config.AuthConfig) (*Service, error) {
+	keyRing, err := NewKeyRing(cfg)
 	if err != nil {
-		// If the key is invalid, generate a new one (should log warning in production)
-		key = paseto.NewV4SymmetricKey()
+		return nil, fmt.Errorf("failed to load paseto keys: %w", err)
 	}
 
 	return &Service{
-		symmetricKey:    key,
-		tokenExpiration: time.Duration(expirationHours) * time.Hour,
-	}
+		keyRing:           keyRing,
+		hasher:            NewPasswordHasher(cfg.Argon2),
+		policy:            NewPasswordPolicy(cfg.PasswordPolicy),
+		accessExpiration:  time.Duration(cfg.AccessTokenExpiration) * time.Minute,
+		refreshExpiration: time.Duration(cfg.RefreshTokenExpiration) * time.Hour,
+	}, nil
 }
 
+// AccessExpiration returns the lifetime of access tokens.
+func (s *Service) AccessExpiration() time.Duration {
+	return s.accessExpiration
+}
+
+// PublicKeys returns the keys other services can use to verify tokens.
+// It is empty unless tokens are issued in public mode.
+func (s *Service) PublicKeys() []PublicKey {
+	return s.keyRing.PublicKeys()
+}
+
+// RefreshExpiration returns the lifetime of refresh tokens and their sessions.
+func (s *Service) RefreshExpiration() time.Duration {
+	return s.refreshExpiration
+}
+
+// HashPassword hashes a password without checking the password policy, e.g.
+// to upgrade the hash of a password that was just verified.
 func (s *Service) HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", fmt.Errorf("failed to hash password: %w", err)
+	return s.hasher.Hash(password)
+}
+
+// HashNewPassword checks a password chosen by the user against the password
+// policy and hashes it. Policy violations are returned as *PasswordPolicyError.
+func (s *Service) HashNewPassword(password, username string) (string, error) {
+	if err := s.policy.Validate(password, username); err != nil {
+		return "", err
 	}
-	return string(hashedPassword), nil
+	return s.hasher.Hash(password)
 }
 
-func (s *Service) VerifyPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+// VerifyPassword checks a password against its stored hash. needsRehash
+// reports that the hash should be upgraded with HashPassword.
+func (s *Service) VerifyPassword(hashedPassword, password string) (needsRehash bool, err error) {
+	return s.hasher.Verify(hashedPassword, password)
 }
 
-func (s *Service) CreateToken(userID int, username string) (string, error) {
+func (s *Service) CreateToken(userID int, username string, sessionID int) (string, error) {
 	now := time.Now()
 	payload := TokenPayload{
-		UserID:   userID,
-		Username: username,
-		IssuedAt: now,
-		ExpireAt: now.Add(s.tokenExpiration),
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+		IssuedAt:  now,
+		ExpireAt:  now.Add(s.accessExpiration),
 	}
 
 	// Marshal payload to JSON
@@ -70,10 +141,8 @@ func (s *Service) CreateToken(userID int, username string) (string, error) {
 	token.SetExpiration(payload.ExpireAt)
 	token.SetString("data", string(payloadJSON))
 
-	// Encrypt the token
-	encrypted := token.V4Encrypt(s.symmetricKey, nil)
-
-	return encrypted, nil
+	// Encrypt or sign the token with the active key
+	return s.keyRing.issue(token, nil)
 }
 
 func (s *Service) VerifyToken(tokenString string) (*TokenPayload, error) {
@@ -81,7 +150,7 @@ func (s *Service) VerifyToken(tokenString string) (*TokenPayload, error) {
 	parser.AddRule(paseto.NotExpired())
 
 	// Parse and verify the token
-	token, err := parser.ParseV4Local(s.symmetricKey, tokenString, nil)
+	token, err := s.keyRing.parse(parser, tokenString, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify token: %w", err)
 	}
@@ -100,3 +169,135 @@ func (s *Service) VerifyToken(tokenString string) (*TokenPayload, error) {
 
 	return &payload, nil
 }
+
+func (s *Service) CreateRefreshToken(sessionID, generation int, expireAt time.Time) (string, error) {
+	now := time.Now()
+	payload := RefreshPayload{
+		SessionID:  sessionID,
+		Generation: generation,
+		ExpireAt:   expireAt,
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	token := paseto.NewToken()
+	token.SetIssuedAt(now)
+	token.SetNotBefore(now)
+	token.SetExpiration(expireAt)
+	token.SetString("data", string(payloadJSON))
+
+	return s.keyRing.issue(token, refreshImplicit)
+}
+
+func (s *Service) VerifyRefreshToken(tokenString string) (*RefreshPayload, error) {
+	parser := paseto.NewParser()
+	parser.AddRule(paseto.NotExpired())
+
+	token, err := s.keyRing.parse(parser, tokenString, refreshImplicit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify refresh token: %w", err)
+	}
+
+	dataStr, err := token.GetString("data")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token data: %w", err)
+	}
+
+	var payload RefreshPayload
+	if err := json.Unmarshal([]byte(dataStr), &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// CreateMFAToken issues a short-lived "MFA pending" token, encrypted in every
+// token mode. The current time is passed in so callers with an injected clock
+// stay consistent.
+func (s *Service) CreateMFAToken(userID int, tokenID string, now time.Time, ttl time.Duration) (string, error) {
+	payload := MFAPayload{
+		UserID:   userID,
+		TokenID:  tokenID,
+		ExpireAt: now.Add(ttl),
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	token := paseto.NewToken()
+	token.SetIssuedAt(now)
+	token.SetNotBefore(now)
+	token.SetExpiration(payload.ExpireAt)
+	token.SetString("data", string(payloadJSON))
+
+	return s.keyRing.seal(token, mfaImplicit)
+}
+
+// VerifyMFAToken verifies an "MFA pending" token as of the given time.
+func (s *Service) VerifyMFAToken(tokenString string, now time.Time) (*MFAPayload, error) {
+	parser := paseto.NewParserWithoutExpiryCheck()
+	parser.AddRule(paseto.ValidAt(now))
+
+	token, err := s.keyRing.open(parser, tokenString, mfaImplicit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify mfa token: %w", err)
+	}
+
+	dataStr, err := token.GetString("data")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token data: %w", err)
+	}
+
+	var payload MFAPayload
+	if err := json.Unmarshal([]byte(dataStr), &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// CreateOIDCStateToken seals the state of an OIDC flow so it can be stored
+// on the client. It is encrypted in every token mode, since it carries the
+// PKCE verifier and the nonce.
+func (s *Service) CreateOIDCStateToken(state OIDCState, now time.Time) (string, error) {
+	payloadJSON, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	token := paseto.NewToken()
+	token.SetIssuedAt(now)
+	token.SetNotBefore(now)
+	token.SetExpiration(state.ExpireAt)
+	token.SetString("data", string(payloadJSON))
+
+	return s.keyRing.seal(token, oidcImplicit)
+}
+
+// VerifyOIDCStateToken verifies an OIDC state token as of the given time.
+func (s *Service) VerifyOIDCStateToken(tokenString string, now time.Time) (*OIDCState, error) {
+	parser := paseto.NewParserWithoutExpiryCheck()
+	parser.AddRule(paseto.ValidAt(now))
+
+	token, err := s.keyRing.open(parser, tokenString, oidcImplicit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify oidc state: %w", err)
+	}
+
+	dataStr, err := token.GetString("data")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token data: %w", err)
+	}
+
+	var state OIDCState
+	if err := json.Unmarshal([]byte(dataStr), &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return &state, nil
+}
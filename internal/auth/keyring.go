@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+)
+
+const (
+	// TokenModeLocal issues v4.local tokens encrypted with a symmetric key.
+	TokenModeLocal = "local"
+	// TokenModePublic issues v4.public tokens signed with an Ed25519 key.
+	TokenModePublic = "public"
+)
+
+var (
+	ErrNoActiveKey = errors.New("no active paseto key configured")
+	ErrUnknownKey  = errors.New("token was issued with an unknown or retired key")
+)
+
+// sealingKeyInfo is the HKDF context of the symmetric keys derived from
+// Ed25519 secret keys to seal internal tokens in public mode.
+const sealingKeyInfo = "chatapp paseto v4.local sealing key"
+
+// keyFooter is stored in the (unencrypted, authenticated) PASETO footer so the
+// verifying key can be picked before the token is opened.
+type keyFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// ringKey is one key of the ring. Depending on the mode either symmetric is
+// set, or public is set together with an optional secret (verify-only keys
+// have no secret). sealing encrypts the tokens only this service reads: the
+// symmetric key itself, or one derived from the secret in public mode.
+type ringKey struct {
+	symmetric paseto.V4SymmetricKey
+	secret    *paseto.V4AsymmetricSecretKey
+	public    paseto.V4AsymmetricPublicKey
+	sealing   *paseto.V4SymmetricKey
+}
+
+// PublicKey describes a verifying key published for other services.
+type PublicKey struct {
+	ID        string
+	Version   string
+	Purpose   string
+	PublicKey string
+	Active    bool
+}
+
+// KeyRing holds every PASETO key that is still accepted, plus the ID of the
+// key used to issue new tokens.
+type KeyRing struct {
+	mode     string
+	activeID string
+	keys     map[string]ringKey
+}
+
+// NewKeyRing builds a key ring from the auth configuration. Invalid keys and a
+// missing or retired active key are hard errors.
+func NewKeyRing(cfg config.AuthConfig) (*KeyRing, error) {
+	mode := cfg.TokenMode
+	if mode == "" {
+		mode = TokenModeLocal
+	}
+	if mode != TokenModeLocal && mode != TokenModePublic {
+		return nil, fmt.Errorf("unknown token mode %q", mode)
+	}
+	if cfg.ActiveKeyID == "" {
+		return nil, ErrNoActiveKey
+	}
+
+	ring := &KeyRing{
+		mode:     mode,
+		activeID: cfg.ActiveKeyID,
+		keys:     make(map[string]ringKey),
+	}
+
+	seen := make(map[string]bool)
+	for _, k := range cfg.Keys {
+		if k.ID == "" {
+			return nil, fmt.Errorf("paseto key without id")
+		}
+		if seen[k.ID] {
+			return nil, fmt.Errorf("duplicate paseto key id %q", k.ID)
+		}
+		seen[k.ID] = true
+
+		if k.Retired {
+			if k.ID == cfg.ActiveKeyID {
+				return nil, fmt.Errorf("active paseto key %q is retired", k.ID)
+			}
+			continue
+		}
+
+		key, err := parseRingKey(mode, k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid paseto key %q: %w", k.ID, err)
+		}
+		ring.keys[k.ID] = key
+	}
+
+	active, ok := ring.keys[cfg.ActiveKeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: key %q not found", ErrNoActiveKey, cfg.ActiveKeyID)
+	}
+	if mode == TokenModePublic && active.secret == nil {
+		return nil, fmt.Errorf("active paseto key %q has no secret key", cfg.ActiveKeyID)
+	}
+
+	return ring, nil
+}
+
+func parseRingKey(mode string, k config.PasetoKeyConfig) (ringKey, error) {
+	if mode == TokenModeLocal {
+		key, err := paseto.V4SymmetricKeyFromHex(k.Key)
+		if err != nil {
+			return ringKey{}, err
+		}
+		return ringKey{symmetric: key, sealing: &key}, nil
+	}
+
+	if k.Key != "" {
+		secret, err := paseto.NewV4AsymmetricSecretKeyFromHex(k.Key)
+		if err != nil {
+			return ringKey{}, err
+		}
+		if k.PublicKey != "" && k.PublicKey != secret.Public().ExportHex() {
+			return ringKey{}, fmt.Errorf("public key does not match secret key")
+		}
+		sealing, err := deriveSealingKey(secret)
+		if err != nil {
+			return ringKey{}, err
+		}
+		return ringKey{secret: &secret, public: secret.Public(), sealing: &sealing}, nil
+	}
+
+	public, err := paseto.NewV4AsymmetricPublicKeyFromHex(k.PublicKey)
+	if err != nil {
+		return ringKey{}, err
+	}
+	return ringKey{public: public}, nil
+}
+
+// deriveSealingKey derives a symmetric key from an Ed25519 secret key.
+func deriveSealingKey(secret paseto.V4AsymmetricSecretKey) (paseto.V4SymmetricKey, error) {
+	derived, err := hkdf.Key(sha256.New, secret.ExportBytes(), nil, sealingKeyInfo, 32)
+	if err != nil {
+		return paseto.V4SymmetricKey{}, fmt.Errorf("failed to derive sealing key: %w", err)
+	}
+	return paseto.V4SymmetricKeyFromBytes(derived)
+}
+
+// Mode returns the configured token mode.
+func (r *KeyRing) Mode() string {
+	return r.mode
+}
+
+// PublicKeys returns the verifying keys of the ring in public mode, sorted by ID.
+func (r *KeyRing) PublicKeys() []PublicKey {
+	if r.mode != TokenModePublic {
+		return nil
+	}
+
+	keys := make([]PublicKey, 0, len(r.keys))
+	for id, k := range r.keys {
+		keys = append(keys, PublicKey{
+			ID:        id,
+			Version:   "v4",
+			Purpose:   "public",
+			PublicKey: k.public.ExportHex(),
+			Active:    id == r.activeID,
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	return keys
+}
+
+// issue encrypts or signs the token with the active key and records its ID in the footer.
+func (r *KeyRing) issue(token paseto.Token, implicit []byte) (string, error) {
+	footer, err := json.Marshal(keyFooter{KeyID: r.activeID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal footer: %w", err)
+	}
+	token.SetFooter(footer)
+
+	active := r.keys[r.activeID]
+	if r.mode == TokenModePublic {
+		return token.V4Sign(*active.secret, implicit), nil
+	}
+	return token.V4Encrypt(active.symmetric, implicit), nil
+}
+
+// parse looks up the key named in the token footer and verifies the token with it.
+func (r *KeyRing) parse(parser paseto.Parser, tokenString string, implicit []byte) (*paseto.Token, error) {
+	protocol := paseto.V4Local
+	if r.mode == TokenModePublic {
+		protocol = paseto.V4Public
+	}
+
+	footerBytes, err := parser.UnsafeParseFooter(protocol, tokenString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token footer: %w", err)
+	}
+
+	var footer keyFooter
+	if err := json.Unmarshal(footerBytes, &footer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token footer: %w", err)
+	}
+
+	key, ok := r.keys[footer.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	if r.mode == TokenModePublic {
+		return parser.ParseV4Public(key.public, tokenString, implicit)
+	}
+	return parser.ParseV4Local(key.symmetric, tokenString, implicit)
+}
+
+// seal encrypts a token that only this service reads, such as the state of an
+// OIDC flow, with the active key. Unlike issue it always produces v4.local,
+// so the payload stays confidential in public mode as well.
+func (r *KeyRing) seal(token paseto.Token, implicit []byte) (string, error) {
+	footer, err := json.Marshal(keyFooter{KeyID: r.activeID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal footer: %w", err)
+	}
+	token.SetFooter(footer)
+
+	return token.V4Encrypt(*r.keys[r.activeID].sealing, implicit), nil
+}
+
+// open decrypts a token made by seal with the key named in its footer.
+func (r *KeyRing) open(parser paseto.Parser, tokenString string, implicit []byte) (*paseto.Token, error) {
+	footerBytes, err := parser.UnsafeParseFooter(paseto.V4Local, tokenString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token footer: %w", err)
+	}
+
+	var footer keyFooter
+	if err := json.Unmarshal(footerBytes, &footer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token footer: %w", err)
+	}
+
+	key, ok := r.keys[footer.KeyID]
+	if !ok || key.sealing == nil {
+		return nil, ErrUnknownKey
+	}
+
+	return parser.ParseV4Local(*key.sealing, tokenString, implicit)
+}
+
+// GenerateKey returns a new random hex-encoded symmetric key.
+func GenerateKey() string {
+	return paseto.NewV4SymmetricKey().ExportHex()
+}
+
+// GenerateKeyPair returns a new random hex-encoded Ed25519 secret and public key.
+func GenerateKeyPair() (secret, public string) {
+	key := paseto.NewV4AsymmetricSecretKey()
+	return key.ExportHex(), key.Public().ExportHex()
+}
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+)
+
+// Lockout describes a key that just got locked because of too many failures.
+type Lockout struct {
+	Kind     string // "username" or "ip"
+	Value    string
+	Failures int
+	Until    time.Time
+}
+
+type guardKey struct {
+	namespace   string
+	kind        string
+	value       string
+	maxAttempts int
+}
+
+func (k guardKey) String() string {
+	if k.namespace != "" {
+		return k.namespace + ":" + k.kind + ":" + k.value
+	}
+	return k.kind + ":" + k.value
+}
+
+// LoginGuard throttles password guessing. Every failed login of a username and
+// of an IP address delays the next attempt exponentially, and after too many
+// failures the username or IP is locked out for a while.
+type LoginGuard struct {
+	namespace       string
+	store           AttemptStore
+	clock           Clock
+	maxAttempts     int
+	ipMaxAttempts   int
+	baseDelay       time.Duration
+	maxDelay        time.Duration
+	lockoutDuration time.Duration
+	window          time.Duration
+}
+
+func NewLoginGuard(store AttemptStore, cfg config.LoginProtectionConfig, clock Clock) *LoginGuard {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &LoginGuard{
+		store:           store,
+		clock:           clock,
+		maxAttempts:     cfg.MaxAttempts,
+		ipMaxAttempts:   cfg.IPMaxAttempts,
+		baseDelay:       time.Duration(cfg.BaseDelay) * time.Second,
+		maxDelay:        time.Duration(cfg.MaxDelay) * time.Second,
+		lockoutDuration: time.Duration(cfg.LockoutDuration) * time.Minute,
+		window:          time.Duration(cfg.Window) * time.Minute,
+	}
+}
+
+// Namespace returns a guard with the same settings and store that counts
+// attempts separately, e.g. for another endpoint that must be throttled.
+func (g *LoginGuard) Namespace(namespace string) *LoginGuard {
+	guard := *g
+	guard.namespace = namespace
+	return &guard
+}
+
+// Check returns how long the caller has to wait before another attempt for the
+// username from the IP is allowed. Zero means the attempt may proceed. An empty
+// username only checks the IP.
+func (g *LoginGuard) Check(ctx context.Context, username, ip string) (time.Duration, error) {
+	now := g.clock.Now()
+
+	var wait time.Duration
+	for _, key := range g.keys(username, ip) {
+		state, err := g.store.Get(ctx, key.String())
+		if err != nil {
+			return 0, err
+		}
+
+		if d := state.LockedUntil.Sub(now); d > wait {
+			wait = d
+		}
+		if state.Failures > 0 {
+			if d := state.LastFailure.Add(g.delay(state.Failures)).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+
+	return wait, nil
+}
+
+// RecordFailure counts a failed attempt and returns the keys that got locked
+// out by it.
+func (g *LoginGuard) RecordFailure(ctx context.Context, username, ip string) ([]Lockout, error) {
+	now := g.clock.Now()
+
+	var lockouts []Lockout
+	for _, key := range g.keys(username, ip) {
+		state, err := g.store.RecordFailure(ctx, key.String(), now, g.window)
+		if err != nil {
+			return lockouts, err
+		}
+
+		if key.maxAttempts > 0 && state.Failures >= key.maxAttempts && !state.LockedUntil.After(now) {
+			until := now.Add(g.lockoutDuration)
+			if err := g.store.Lock(ctx, key.String(), until); err != nil {
+				return lockouts, err
+			}
+			lockouts = append(lockouts, Lockout{
+				Kind:     key.kind,
+				Value:    key.value,
+				Failures: state.Failures,
+				Until:    until,
+			})
+		}
+	}
+
+	return lockouts, nil
+}
+
+// RecordSuccess forgets the failures of the username. IP failures are kept so
+// an attacker cannot reset them by logging into an account of their own.
+func (g *LoginGuard) RecordSuccess(ctx context.Context, username string) error {
+	if username = normalizeUsername(username); username == "" {
+		return nil
+	}
+	return g.store.Reset(ctx, guardKey{namespace: g.namespace, kind: "username", value: username}.String())
+}
+
+func (g *LoginGuard) keys(username, ip string) []guardKey {
+	keys := make([]guardKey, 0, 2)
+	if username = normalizeUsername(username); username != "" {
+		keys = append(keys, guardKey{namespace: g.namespace, kind: "username", value: username, maxAttempts: g.maxAttempts})
+	}
+	if ip != "" {
+		keys = append(keys, guardKey{namespace: g.namespace, kind: "ip", value: ip, maxAttempts: g.ipMaxAttempts})
+	}
+	return keys
+}
+
+// delay returns the backoff after the given number of consecutive failures:
+// the base delay doubled for every failure after the first, capped at maxDelay.
+func (g *LoginGuard) delay(failures int) time.Duration {
+	delay := g.baseDelay
+	for i := 1; i < failures && delay < g.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > g.maxDelay {
+		delay = g.maxDelay
+	}
+	return delay
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordMismatch    = errors.New("password does not match")
+	ErrUnknownPasswordHash = errors.New("unknown password hash format")
+)
+
+// UnusablePasswordPrefix marks stored passwords of accounts that cannot log in
+// with a password, such as bots and users provisioned through single sign-on.
+// Such values never verify.
+const UnusablePasswordPrefix = "!"
+
+// HasUsablePassword reports whether a stored password hash can ever verify.
+func HasUsablePassword(encoded string) bool {
+	return !strings.HasPrefix(encoded, UnusablePasswordPrefix)
+}
+
+// argon2Params are the parameters encoded in an argon2id hash.
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+// PasswordHasher hashes passwords with argon2id and verifies both argon2id and
+// legacy bcrypt hashes. Hashes use the PHC string format, so every hash
+// carries the parameters it was created with:
+//
+//	$argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>
+type PasswordHasher struct {
+	params argon2Params
+}
+
+func NewPasswordHasher(cfg config.Argon2Config) *PasswordHasher {
+	return &PasswordHasher{
+		params: argon2Params{
+			memory:      cfg.Memory,
+			iterations:  cfg.Iterations,
+			parallelism: cfg.Parallelism,
+			saltLength:  cfg.SaltLength,
+			keyLength:   cfg.KeyLength,
+		},
+	}
+}
+
+// Hash returns the encoded argon2id hash of password.
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.iterations, h.params.memory, h.params.parallelism, h.params.keyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.memory, h.params.iterations, h.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks password against an encoded hash. needsRehash reports that
+// the password matched but the hash is bcrypt or uses outdated parameters and
+// should be replaced with a fresh Hash.
+func (h *PasswordHasher) Verify(encoded, password string) (needsRehash bool, err error) {
+	if strings.HasPrefix(encoded, "$2") {
+		if err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err != nil {
+			return false, ErrPasswordMismatch
+		}
+		return true, nil
+	}
+
+	params, salt, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, params.keyLength)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, ErrPasswordMismatch
+	}
+
+	return params != h.params, nil
+}
+
+func decodeArgon2Hash(encoded string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+	params.saltLength = uint32(len(salt))
+	params.keyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,30 @@
+package auth
+
+// API token scopes. User sessions are not limited by scopes; bots can only
+// call endpoints covered by the scopes of their token.
+const (
+	ScopeUsersRead     = "users:read"
+	ScopeChatsRead     = "chats:read"
+	ScopeChatsWrite    = "chats:write"
+	ScopeMessagesRead  = "messages:read"
+	ScopeMessagesWrite = "messages:write"
+)
+
+// Scopes lists every scope an API token can be granted.
+var Scopes = []string{
+	ScopeUsersRead,
+	ScopeChatsRead,
+	ScopeChatsWrite,
+	ScopeMessagesRead,
+	ScopeMessagesWrite,
+}
+
+// IsValidScope reports whether scope is a known scope.
+func IsValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrInvalidTicket = errors.New("invalid or expired ticket")
+
+// Ticket is what a connection ticket stands for: the session of the user that
+// requested it.
+type Ticket struct {
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	SessionID int    `json:"session_id"`
+}
+
+// TicketStore keeps issued tickets until they are redeemed or expire.
+// Implementations must be safe for concurrent use; the Redis implementation
+// shares tickets across instances.
+type TicketStore interface {
+	// Put stores the ticket under key for ttl.
+	Put(ctx context.Context, key string, ticket Ticket, ttl time.Duration) error
+	// Take returns the ticket stored under key and removes it atomically, so
+	// each ticket can only be taken once. ok is false if there is none.
+	Take(ctx context.Context, key string) (ticket Ticket, ok bool, err error)
+}
+
+// TicketIssuer hands out short-lived, single-use tickets for opening WebSocket
+// connections, so access tokens never have to appear in URLs.
+type TicketIssuer struct {
+	store TicketStore
+	clock Clock
+	ttl   time.Duration
+}
+
+func NewTicketIssuer(store TicketStore, ttl time.Duration, clock Clock) *TicketIssuer {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &TicketIssuer{
+		store: store,
+		clock: clock,
+		ttl:   ttl,
+	}
+}
+
+// Issue returns a new ticket for the session and when it expires.
+func (i *TicketIssuer) Issue(ctx context.Context, ticket Ticket) (string, time.Time, error) {
+	value, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := i.store.Put(ctx, HashOpaqueToken(value), ticket, i.ttl); err != nil {
+		return "", time.Time{}, err
+	}
+	return value, i.clock.Now().Add(i.ttl), nil
+}
+
+// Redeem consumes a ticket. A ticket is only accepted once.
+func (i *TicketIssuer) Redeem(ctx context.Context, value string) (Ticket, error) {
+	if value == "" {
+		return Ticket{}, ErrInvalidTicket
+	}
+	ticket, ok, err := i.store.Take(ctx, HashOpaqueToken(value))
+	if err != nil {
+		return Ticket{}, err
+	}
+	if !ok {
+		return Ticket{}, ErrInvalidTicket
+	}
+	return ticket, nil
+}
+
+type memoryTicket struct {
+	ticket    Ticket
+	expiresAt time.Time
+}
+
+// MemoryTicketStore keeps tickets in process memory. It is suitable for a
+// single instance only.
+type MemoryTicketStore struct {
+	mu        sync.Mutex
+	tickets   map[string]memoryTicket
+	clock     Clock
+	lastSweep time.Time
+}
+
+func NewMemoryTicketStore(clock Clock) *MemoryTicketStore {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &MemoryTicketStore{
+		tickets: make(map[string]memoryTicket),
+		clock:   clock,
+	}
+}
+
+func (s *MemoryTicketStore) Put(_ context.Context, key string, ticket Ticket, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep()
+	s.tickets[key] = memoryTicket{
+		ticket:    ticket,
+		expiresAt: s.clock.Now().Add(ttl),
+	}
+	return nil
+}
+
+func (s *MemoryTicketStore) Take(_ context.Context, key string) (Ticket, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[key]
+	if !ok {
+		return Ticket{}, false, nil
+	}
+	delete(s.tickets, key)
+	if !s.clock.Now().Before(t.expiresAt) {
+		return Ticket{}, false, nil
+	}
+	return t.ticket, true, nil
+}
+
+// sweep drops expired tickets that were never redeemed, at most once a
+// minute. The caller must hold the lock.
+func (s *MemoryTicketStore) sweep() {
+	now := s.clock.Now()
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, t := range s.tickets {
+		if !now.Before(t.expiresAt) {
+			delete(s.tickets, key)
+		}
+	}
+}
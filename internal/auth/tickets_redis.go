@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisTicketPrefix = "chatapp:ws_tickets:"
+
+// RedisTicketStore keeps tickets in Redis so a ticket issued by one instance
+// can be redeemed on another.
+type RedisTicketStore struct {
+	client *redis.Client
+}
+
+func NewRedisTicketStore(client *redis.Client) *RedisTicketStore {
+	return &RedisTicketStore{client: client}
+}
+
+func (s *RedisTicketStore) Put(ctx context.Context, key string, ticket Ticket, ttl time.Duration) error {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket: %w", err)
+	}
+	if err := s.client.Set(ctx, redisTicketPrefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store ticket: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisTicketStore) Take(ctx context.Context, key string) (Ticket, bool, error) {
+	// GETDEL makes sure concurrent redemptions of the same ticket get it once
+	data, err := s.client.GetDel(ctx, redisTicketPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return Ticket{}, false, nil
+		}
+		return Ticket{}, false, fmt.Errorf("failed to take ticket: %w", err)
+	}
+
+	var ticket Ticket
+	if err := json.Unmarshal(data, &ticket); err != nil {
+		return Ticket{}, false, fmt.Errorf("failed to decode ticket: %w", err)
+	}
+	return ticket, true, nil
+}
@@ -0,0 +1,88 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+)
+
+// Types of domain events
+const (
+	MessageCreated   = "message.created"
+	MessageUpdated   = "message.updated"
+	MessageDeleted   = "message.deleted"
+	MessageDelivered = "message.delivered"
+	ThreadUpdated    = "thread.updated"
+	ReactionAdded    = "reaction.added"
+	ReactionRemoved  = "reaction.removed"
+	ChatCreated      = "chat.created"
+	ChatUpdated      = "chat.updated"
+	ChatDeleted      = "chat.deleted"
+	MemberAdded      = "member.added"
+	MemberRemoved    = "member.removed"
+	Read             = "read"
+)
+
+// Event describes a change to a chat or its messages.
+type Event struct {
+	Type   string
+	ChatID int
+	// Recipients are the users that have to learn about the change: the
+	// members of the chat, including members that were just removed and the
+	// members of a deleted chat.
+	Recipients []int
+
+	Message   *ent.Message // message and reaction events, with the sender loaded; the root for thread.updated
+	Chat      *ent.Chat    // chat.created and chat.updated, with the creator loaded
+	MemberIDs []int        // member events
+	// Member is the member who read the chat, with the user and the new
+	// read cursor, for read events
+	Member *ent.ChatMember
+	UserID int    // the member who reacted, for reaction events
+	Emoji  string // reaction events
+	// Deliveries are the messages of the sender, the only recipient, that
+	// reached other members, for message.delivered
+	Deliveries []Delivery
+}
+
+// Delivery is a message that reached one of its recipients.
+type Delivery struct {
+	MessageID   int
+	ChatID      int
+	UserID      int
+	DeliveredAt time.Time
+}
+
+// Handler is called with every published event.
+type Handler func(ctx context.Context, e Event)
+
+// Bus passes the events the services publish to the subscribed handlers.
+// Handlers run synchronously in the order they subscribed.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for all events.
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish calls every handler with the event.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, e)
+	}
+}
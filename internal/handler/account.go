@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/gofiber/fiber/v3"
+)
+
+type AccountHandler struct {
+	accountService *service.AccountService
+	// resetGuard throttles password reset requests per email address and IP
+	resetGuard *auth.LoginGuard
+}
+
+func NewAccountHandler(accountService *service.AccountService, resetGuard *auth.LoginGuard) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		resetGuard:     resetGuard,
+	}
+}
+
+// SendVerificationEmail sends a new verification link to the user's email.
+func (h *AccountHandler) SendVerificationEmail(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	err := h.accountService.SendVerificationEmail(context.Background(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmailNotSet):
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "no email address set",
+			})
+		case errors.Is(err, service.ErrEmailAlreadyVerified):
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+				Error: "email address is already verified",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to send verification email",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).Send(nil)
+}
+
+func (h *AccountHandler) VerifyEmail(c fiber.Ctx) error {
+	req := new(model.VerifyEmailRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	if err := h.accountService.VerifyEmail(context.Background(), req.Token); err != nil {
+		return h.respondTokenError(c, err, "failed to verify email")
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ForgotPassword always answers 202 so it cannot be used to find out which
+// email addresses have an account. Every request counts against the email
+// address and the IP, so it cannot be used to flood a mailbox either.
+func (h *AccountHandler) ForgotPassword(c fiber.Ctx) error {
+	req := new(model.ForgotPasswordRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	retryAfter, err := h.resetGuard.Check(context.Background(), req.Email, c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to request password reset",
+		})
+	}
+	if retryAfter > 0 {
+		return respondRateLimited(c, retryAfter, "too many password reset requests, try again later")
+	}
+	if _, err := h.resetGuard.RecordFailure(context.Background(), req.Email, c.IP()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to request password reset",
+		})
+	}
+
+	if err := h.accountService.RequestPasswordReset(context.Background(), req.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to request password reset",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).Send(nil)
+}
+
+func (h *AccountHandler) ResetPassword(c fiber.Ctx) error {
+	req := new(model.ResetPasswordRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	if err := h.accountService.ResetPassword(context.Background(), req.Token, req.NewPassword); err != nil {
+		if policyErr, ok := asPasswordPolicyError(err); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: policyErr.Error(),
+			})
+		}
+		return h.respondTokenError(c, err, "failed to reset password")
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+func (h *AccountHandler) respondTokenError(c fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, service.ErrInvalidAccountToken) {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid or expired token",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+		Error: fallback,
+	})
+}
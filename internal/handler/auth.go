@@ -3,25 +3,42 @@ package handler
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
 	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/utils"
 	"github.com/gofiber/fiber/v3"
 )
 
 type AuthHandler struct {
-	userService *service.UserService
-	authService *auth.Service
+	userService    *service.UserService
+	sessionService *service.SessionService
+	mfaService     *service.MFAService
+	accountService *service.AccountService
+	auditService   *service.AuditService
+	authService    *auth.Service
+	loginGuard     *auth.LoginGuard
 }
 
-func NewAuthHandler(client *ent.Client, authService *auth.Service) *AuthHandler {
+func NewAuthHandler(client *ent.Client, authService *auth.Service, sessionService *service.SessionService, mfaService *service.MFAService, accountService *service.AccountService, loginGuard *auth.LoginGuard) *AuthHandler {
 	return &AuthHandler{
-		userService: service.NewUserService(client, authService),
-		authService: authService,
+		userService:    service.NewUserService(client, authService),
+		sessionService: sessionService,
+		mfaService:     mfaService,
+		accountService: accountService,
+		auditService:   service.NewAuditService(client),
+		authService:    authService,
+		loginGuard:     loginGuard,
 	}
 }
 
@@ -39,13 +56,6 @@ func (h *AuthHandler) Register(c fiber.Ctx) error {
 		})
 	}
 
-	// Validate password
-	if len(req.Password) < 6 {
-		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
-			Error: "password must be at least 6 characters",
-		})
-	}
-
 	// Check if user exists
 	exists, err := h.userService.UserExists(context.Background(), req.Username)
 	if err != nil {
@@ -59,16 +69,44 @@ func (h *AuthHandler) Register(c fiber.Ctx) error {
 		})
 	}
 
+	// Check if email is taken
+	if req.Email != "" {
+		exists, err := h.userService.EmailExists(context.Background(), req.Email)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+				Error: "failed to check email existence",
+			})
+		}
+		if exists {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+				Error: "email already in use",
+			})
+		}
+	}
+
 	// Create user
-	newUser, err := h.userService.CreateUser(context.Background(), req.Username, req.Password, req.DisplayName)
+	newUser, err := h.userService.CreateUser(context.Background(), req.Username, req.Password, req.DisplayName, req.Email)
 	if err != nil {
+		if policyErr, ok := asPasswordPolicyError(err); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: policyErr.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
 			Error: "failed to create user",
 		})
 	}
 
-	// Generate token
-	token, err := h.userService.CreateToken(newUser.ID, newUser.Username)
+	// A failed verification email must not fail the registration, the user
+	// can ask for a new one
+	if newUser.Email != nil {
+		if err := h.accountService.SendVerificationEmail(context.Background(), newUser.ID); err != nil {
+			log.Printf("Error sending verification email to user %d: %v", newUser.ID, err)
+		}
+	}
+
+	// Start a session and generate tokens
+	tokens, err := h.sessionService.StartSession(context.Background(), newUser.ID, newUser.Username, sessionMetadata(c, req.DeviceName))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
 			Error: "failed to generate token",
@@ -76,14 +114,10 @@ func (h *AuthHandler) Register(c fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(model.AuthResponse{
-		Token: token,
-		User: model.UserProfile{
-			ID:          newUser.ID,
-			Username:    newUser.Username,
-			DisplayName: newUser.DisplayName,
-			CreatedAt:   newUser.CreatedAt,
-			LastSeen:    newUser.LastSeen,
-		},
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+		User:         ownUserProfile(newUser),
 	})
 }
 
@@ -93,23 +127,115 @@ func (h *AuthHandler) Login(c fiber.Ctx) error {
 		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
 	}
 
+	// Throttle repeated failures for this username and IP
+	retryAfter, err := h.loginGuard.Check(context.Background(), req.Username, c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check login attempts",
+		})
+	}
+	if retryAfter > 0 {
+		return respondTooManyAttempts(c, retryAfter)
+	}
+
 	// Find user
 	u, err := h.userService.GetUserByUsername(context.Background(), req.Username)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
-			Error: "invalid credentials",
-		})
+		return h.loginFailed(c, req.Username, 0)
+	}
+
+	// Bots authenticate with API tokens only
+	if u.Type == user.TypeBot {
+		return h.loginFailed(c, req.Username, u.ID)
 	}
 
 	// Verify password
-	if err := h.userService.VerifyPassword(u.Password, req.Password); err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
-			Error: "invalid credentials",
+	needsRehash, err := h.userService.VerifyPassword(u.Password, req.Password)
+	if err != nil {
+		return h.loginFailed(c, req.Username, u.ID)
+	}
+
+	// Upgrade bcrypt and outdated argon2id hashes while the password is at hand
+	if needsRehash {
+		if err := h.userService.UpgradePasswordHash(context.Background(), u.ID, req.Password); err != nil {
+			log.Printf("Error upgrading password hash of user %d: %v", u.ID, err)
+		}
+	}
+
+	if err := h.loginGuard.RecordSuccess(context.Background(), req.Username); err != nil {
+		log.Printf("Error resetting login attempts for %q: %v", req.Username, err)
+	}
+
+	// Users with 2FA get a short-lived pending token for the second step
+	if u.TotpEnabled {
+		mfaToken, expiresAt, err := h.mfaService.CreatePendingToken(context.Background(), u.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+				Error: "failed to generate token",
+			})
+		}
+
+		return c.JSON(model.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresAt:   expiresAt,
+		})
+	}
+
+	// Start a session and generate tokens
+	tokens, err := h.sessionService.StartSession(context.Background(), u.ID, u.Username, sessionMetadata(c, req.DeviceName))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to generate token",
+		})
+	}
+
+	return c.JSON(model.AuthResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+		User:         ownUserProfile(u),
+	})
+}
+
+// LoginMFA completes a two-step login with the pending token and a TOTP or
+// recovery code.
+func (h *AuthHandler) LoginMFA(c fiber.Ctx) error {
+	req := new(model.MFALoginRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	// Code guessing is throttled per IP, the pending token carries no username
+	retryAfter, err := h.loginGuard.Check(context.Background(), "", c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check login attempts",
+		})
+	}
+	if retryAfter > 0 {
+		return respondTooManyAttempts(c, retryAfter)
+	}
+
+	u, err := h.mfaService.CompleteLogin(context.Background(), req.MFAToken, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidMFAToken), errors.Is(err, service.ErrMFANotEnabled):
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "invalid or expired mfa token",
+			})
+		case errors.Is(err, service.ErrInvalidMFACode):
+			h.recordLoginFailure(c, "", 0)
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "invalid two-factor code",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to verify two-factor code",
 		})
 	}
 
-	// Generate token
-	token, err := h.userService.CreateToken(u.ID, u.Username)
+	tokens, err := h.sessionService.StartSession(context.Background(), u.ID, u.Username, sessionMetadata(c, req.DeviceName))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
 			Error: "failed to generate token",
@@ -117,17 +243,150 @@ func (h *AuthHandler) Login(c fiber.Ctx) error {
 	}
 
 	return c.JSON(model.AuthResponse{
-		Token: token,
-		User: model.UserProfile{
-			ID:          u.ID,
-			Username:    u.Username,
-			DisplayName: u.DisplayName,
-			CreatedAt:   u.CreatedAt,
-			LastSeen:    u.LastSeen,
-		},
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+		User:         ownUserProfile(u),
+	})
+}
+
+func (h *AuthHandler) Refresh(c fiber.Ctx) error {
+	req := new(model.RefreshTokenRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	tokens, err := h.sessionService.Refresh(context.Background(), req.RefreshToken, sessionMetadata(c, ""))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRefreshTokenReused):
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "refresh token reuse detected, session revoked",
+			})
+		case errors.Is(err, service.ErrSessionRevoked), errors.Is(err, service.ErrSessionExpired):
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "session is no longer valid",
+			})
+		case errors.Is(err, service.ErrInvalidRefreshToken):
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "invalid or expired refresh token",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to refresh token",
+		})
+	}
+
+	return c.JSON(model.TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
 	})
 }
 
+func (h *AuthHandler) Logout(c fiber.Ctx) error {
+	sessionID := c.Locals("session_id").(int)
+
+	if err := h.sessionService.RevokeSession(context.Background(), sessionID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to log out",
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+func (h *AuthHandler) ListSessions(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	currentSessionID := c.Locals("session_id").(int)
+
+	sessions, err := h.sessionService.ListUserSessions(context.Background(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list sessions",
+		})
+	}
+
+	sessionResponses := make([]model.SessionResponse, 0, len(sessions))
+	for _, sess := range sessions {
+		sessionResponses = append(sessionResponses, model.SessionResponse{
+			ID:         sess.ID,
+			DeviceName: sess.DeviceName,
+			IPAddress:  sess.IPAddress,
+			UserAgent:  sess.UserAgent,
+			Current:    sess.ID == currentSessionID,
+			CreatedAt:  sess.CreatedAt,
+			LastUsedAt: sess.LastUsedAt,
+			ExpiresAt:  sess.ExpiresAt,
+		})
+	}
+
+	return c.JSON(sessionResponses)
+}
+
+func (h *AuthHandler) RevokeSession(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	sessionID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid session id",
+		})
+	}
+
+	err = h.sessionService.RevokeUserSession(context.Background(), userID, sessionID)
+	if err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+				Error: "session not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to revoke session",
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	currentSessionID := c.Locals("session_id").(int)
+
+	err := h.sessionService.RevokeOtherSessions(context.Background(), userID, currentSessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to revoke sessions",
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// PublicKeys publishes the PASETO verifying keys, including rotated ones that
+// are still accepted, so other services can verify v4.public tokens.
+func (h *AuthHandler) PublicKeys(c fiber.Ctx) error {
+	keys := h.authService.PublicKeys()
+	if len(keys) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "public token mode is not enabled",
+		})
+	}
+
+	keyResponses := make([]model.PublicKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		keyResponses = append(keyResponses, model.PublicKeyResponse{
+			KeyID:     k.ID,
+			Version:   k.Version,
+			Purpose:   k.Purpose,
+			PublicKey: k.PublicKey,
+			Active:    k.Active,
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(model.PublicKeysResponse{Keys: keyResponses})
+}
+
 func (h *AuthHandler) GetMe(c fiber.Ctx) error {
 	userID := c.Locals("user_id").(int)
 
@@ -138,15 +397,83 @@ func (h *AuthHandler) GetMe(c fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(model.UserProfile{
-		ID:          u.ID,
-		Username:    u.Username,
-		DisplayName: u.DisplayName,
-		CreatedAt:   u.CreatedAt,
-		LastSeen:    u.LastSeen,
+	return c.JSON(ownUserProfile(u))
+}
+
+// loginFailed records a failed password attempt and responds with the same
+// error whether or not the user exists.
+func (h *AuthHandler) loginFailed(c fiber.Ctx, username string, userID int) error {
+	h.recordLoginFailure(c, username, userID)
+
+	return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+		Error: "invalid credentials",
 	})
 }
 
+// recordLoginFailure counts a failed attempt and audits any lockout it caused.
+// Errors are only logged so the client response is never affected.
+func (h *AuthHandler) recordLoginFailure(c fiber.Ctx, username string, userID int) {
+	recordGuardFailure(c, h.loginGuard, h.auditService, username, userID)
+}
+
+// recordGuardFailure counts a wrong password or code against the username
+// and IP, and audits the lockouts it causes.
+func recordGuardFailure(c fiber.Ctx, guard *auth.LoginGuard, auditService *service.AuditService, username string, userID int) {
+	lockouts, err := guard.RecordFailure(context.Background(), username, c.IP())
+	if err != nil {
+		log.Printf("Error recording failed login for %q: %v", username, err)
+	}
+
+	for _, lockout := range lockouts {
+		entry := service.AuditEntry{
+			Action:    service.AuditLoginLockout,
+			IPAddress: c.IP(),
+			Details: map[string]interface{}{
+				"kind":     lockout.Kind,
+				"value":    lockout.Value,
+				"failures": lockout.Failures,
+				"until":    lockout.Until,
+			},
+		}
+		if lockout.Kind == "username" {
+			entry.UserID = userID
+		}
+
+		if err := auditService.Record(context.Background(), entry); err != nil {
+			log.Printf("Error recording lockout audit log: %v", err)
+		}
+		log.Printf("Login locked out for %s %q until %s", lockout.Kind, lockout.Value, lockout.Until.Format(time.RFC3339))
+	}
+}
+
+// asPasswordPolicyError unwraps the policy violations of a rejected password.
+func asPasswordPolicyError(err error) (*auth.PasswordPolicyError, bool) {
+	var policyErr *auth.PasswordPolicyError
+	ok := errors.As(err, &policyErr)
+	return policyErr, ok
+}
+
+func respondTooManyAttempts(c fiber.Ctx, retryAfter time.Duration) error {
+	return respondRateLimited(c, retryAfter, "too many failed login attempts, try again later")
+}
+
+func respondRateLimited(c fiber.Ctx, retryAfter time.Duration, message string) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+
+	return c.Status(fiber.StatusTooManyRequests).JSON(model.ErrorResponse{
+		Error: message,
+	})
+}
+
+func sessionMetadata(c fiber.Ctx, deviceName string) service.SessionMetadata {
+	return service.SessionMetadata{
+		DeviceName: strings.TrimSpace(deviceName),
+		IPAddress:  c.IP(),
+		UserAgent:  c.Get(fiber.HeaderUserAgent),
+	}
+}
+
 func convertNullTimeToPointer(nt sql.NullTime) *interface{} {
 	if !nt.Valid {
 		return nil
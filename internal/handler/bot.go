@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/utils"
+	"github.com/gofiber/fiber/v3"
+)
+
+type BotHandler struct {
+	botService   *service.BotService
+	userService  *service.UserService
+	auditService *service.AuditService
+}
+
+func NewBotHandler(client *ent.Client, authService *auth.Service, botService *service.BotService) *BotHandler {
+	return &BotHandler{
+		botService:   botService,
+		userService:  service.NewUserService(client, authService),
+		auditService: service.NewAuditService(client),
+	}
+}
+
+func (h *BotHandler) CreateBot(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	req := new(model.CreateBotRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if len(req.Username) < 3 || len(req.Username) > 50 {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "username must be between 3 and 50 characters",
+		})
+	}
+
+	exists, err := h.userService.UserExists(context.Background(), req.Username)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check user existence",
+		})
+	}
+	if exists {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+			Error: "username already exists",
+		})
+	}
+
+	bot, err := h.botService.CreateBot(context.Background(), userID, req.Username, req.DisplayName)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to create bot",
+		})
+	}
+
+	h.recordAudit(c, service.AuditEntry{
+		Action:    service.AuditBotCreated,
+		UserID:    userID,
+		IPAddress: c.IP(),
+		Details: map[string]interface{}{
+			"bot_id":       bot.ID,
+			"bot_username": bot.Username,
+		},
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(botProfile(bot))
+}
+
+func (h *BotHandler) ListBots(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	bots, err := h.botService.ListBots(context.Background(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list bots",
+		})
+	}
+
+	botProfiles := make([]model.UserProfile, len(bots))
+	for i, bot := range bots {
+		botProfiles[i] = botProfile(bot)
+	}
+
+	return c.JSON(botProfiles)
+}
+
+func (h *BotHandler) DeleteBot(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	botID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid bot id",
+		})
+	}
+
+	if err := h.botService.DeleteBot(context.Background(), userID, botID); err != nil {
+		return h.respondBotError(c, err, "failed to delete bot")
+	}
+
+	h.recordAudit(c, service.AuditEntry{
+		Action:    service.AuditBotDeleted,
+		UserID:    userID,
+		IPAddress: c.IP(),
+		Details: map[string]interface{}{
+			"bot_id": botID,
+		},
+	})
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// CreateAPIToken issues a token for a bot. The plain token is only shown in
+// this response.
+func (h *BotHandler) CreateAPIToken(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	botID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid bot id",
+		})
+	}
+
+	req := new(model.CreateAPITokenRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		t := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	token, apiToken, err := h.botService.CreateAPIToken(context.Background(), userID, botID, req.Name, req.Scopes, expiresAt)
+	if err != nil {
+		return h.respondBotError(c, err, "failed to create api token")
+	}
+
+	h.recordAudit(c, service.AuditEntry{
+		Action:    service.AuditAPITokenCreated,
+		UserID:    userID,
+		IPAddress: c.IP(),
+		Details: map[string]interface{}{
+			"bot_id":   botID,
+			"token_id": apiToken.ID,
+			"scopes":   apiToken.Scopes,
+		},
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(model.CreatedAPITokenResponse{
+		APITokenResponse: apiTokenResponse(apiToken),
+		Token:            token,
+	})
+}
+
+func (h *BotHandler) ListAPITokens(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	botID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid bot id",
+		})
+	}
+
+	tokens, err := h.botService.ListAPITokens(context.Background(), userID, botID)
+	if err != nil {
+		return h.respondBotError(c, err, "failed to list api tokens")
+	}
+
+	tokenResponses := make([]model.APITokenResponse, len(tokens))
+	for i, t := range tokens {
+		tokenResponses[i] = apiTokenResponse(t)
+	}
+
+	return c.JSON(tokenResponses)
+}
+
+func (h *BotHandler) RevokeAPIToken(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	botID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid bot id",
+		})
+	}
+
+	tokenID, err := utils.ParamsInt(c, "tokenId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid token id",
+		})
+	}
+
+	if err := h.botService.RevokeAPIToken(context.Background(), userID, botID, tokenID); err != nil {
+		return h.respondBotError(c, err, "failed to revoke api token")
+	}
+
+	h.recordAudit(c, service.AuditEntry{
+		Action:    service.AuditAPITokenRevoked,
+		UserID:    userID,
+		IPAddress: c.IP(),
+		Details: map[string]interface{}{
+			"bot_id":   botID,
+			"token_id": tokenID,
+		},
+	})
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+func (h *BotHandler) respondBotError(c fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrBotNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "bot not found",
+		})
+	case errors.Is(err, service.ErrAPITokenNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "api token not found",
+		})
+	case errors.Is(err, service.ErrInvalidScope):
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+		Error: fallback,
+	})
+}
+
+// recordAudit records an audit event. Errors are only logged so the client
+// response is never affected.
+func (h *BotHandler) recordAudit(c fiber.Ctx, entry service.AuditEntry) {
+	if err := h.auditService.Record(context.Background(), entry); err != nil {
+		log.Printf("Error recording %s audit log: %v", entry.Action, err)
+	}
+}
+
+func botProfile(bot *ent.User) model.UserProfile {
+	return model.UserProfile{
+		ID:          bot.ID,
+		Username:    bot.Username,
+		DisplayName: bot.DisplayName,
+		IsBot:       true,
+		CreatedAt:   bot.CreatedAt,
+		LastSeen:    publicLastSeen(bot),
+	}
+}
+
+func apiTokenResponse(t *ent.APIToken) model.APITokenResponse {
+	return model.APITokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.Scopes,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
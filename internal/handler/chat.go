@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
@@ -11,13 +13,16 @@ import (
 	"github.com/gofiber/fiber/v3"
 )
 
+// snippetLength is how many characters of the last message the chat list shows.
+const snippetLength = 100
+
 type ChatHandler struct {
 	chatService *service.ChatService
 }
 
-func NewChatHandler(client *ent.Client) *ChatHandler {
+func NewChatHandler(client *ent.Client, events *event.Bus) *ChatHandler {
 	return &ChatHandler{
-		chatService: service.NewChatService(client),
+		chatService: service.NewChatService(client, events),
 	}
 }
 
@@ -71,8 +76,9 @@ func (h *ChatHandler) ListChats(c fiber.Ctx) error {
 		})
 	}
 
-	chatResponses := make([]model.ChatDetailResponse, 0, len(chats))
-	for _, chat := range chats {
+	chatResponses := make([]model.ChatSummaryResponse, 0, len(chats))
+	for _, summary := range chats {
+		chat := summary.Chat
 		members := make([]model.ChatMemberResponse, 0, len(chat.Edges.Members))
 		for _, member := range chat.Edges.Members {
 			if member.Edges.User != nil {
@@ -90,17 +96,24 @@ func (h *ChatHandler) ListChats(c fiber.Ctx) error {
 			creatorID = chat.Edges.Creator.ID
 		}
 
-		chatResponses = append(chatResponses, model.ChatDetailResponse{
-			ChatResponse: model.ChatResponse{
-				ID:        chat.ID,
-				Name:      chat.Name,
-				IsGroup:   chat.IsGroup,
-				CreatorID: creatorID,
-				CreatedAt: chat.CreatedAt,
-				UpdatedAt: chat.UpdatedAt,
+		response := model.ChatSummaryResponse{
+			ChatDetailResponse: model.ChatDetailResponse{
+				ChatResponse: model.ChatResponse{
+					ID:        chat.ID,
+					Name:      chat.Name,
+					IsGroup:   chat.IsGroup,
+					CreatorID: creatorID,
+					CreatedAt: chat.CreatedAt,
+					UpdatedAt: chat.UpdatedAt,
+				},
+				Members: members,
 			},
-			Members: members,
-		})
+			UnreadCount: summary.UnreadCount,
+		}
+		if summary.LastMessage != nil {
+			response.LastMessage = messagePreview(summary.LastMessage)
+		}
+		chatResponses = append(chatResponses, response)
 	}
 
 	return c.JSON(chatResponses)
@@ -328,3 +341,207 @@ func (h *ChatHandler) RemoveMember(c fiber.Ctx) error {
 		"message": "member removed successfully",
 	})
 }
+
+// MarkRead moves the read cursor of the current user up to a message of the
+// chat. The cursor never moves back.
+func (h *ChatHandler) MarkRead(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	chatID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid chat id",
+		})
+	}
+
+	req := new(model.MarkReadRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	// Check if user is a member
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	member, err := h.chatService.MarkRead(context.Background(), chatID, userID, req.MessageID)
+	if err != nil {
+		if errors.Is(err, service.ErrMessageNotInChat) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "message does not belong to this chat",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to mark chat as read",
+		})
+	}
+
+	return c.JSON(model.ReadCursorResponse{
+		ChatID:            chatID,
+		LastReadMessageID: member.LastReadMessageID,
+		LastReadAt:        member.LastReadAt,
+	})
+}
+
+// ListEmoji returns the custom emoji of a chat ordered by name.
+func (h *ChatHandler) ListEmoji(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	chatID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid chat id",
+		})
+	}
+
+	// Check if user is a member
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	emoji, err := h.chatService.ListEmoji(context.Background(), chatID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list emoji",
+		})
+	}
+
+	responses := make([]model.ChatEmojiResponse, 0, len(emoji))
+	for _, e := range emoji {
+		responses = append(responses, model.ChatEmojiResponse{
+			Name:      e.Name,
+			ImageURL:  e.ImageURL,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return c.JSON(responses)
+}
+
+// AddEmoji adds a custom emoji the members can react with as :name:.
+func (h *ChatHandler) AddEmoji(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	chatID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid chat id",
+		})
+	}
+
+	// Check if user is admin
+	isAdmin, err := h.chatService.IsUserAdminOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "only admins can add emoji",
+		})
+	}
+
+	req := new(model.CreateChatEmojiRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	created, err := h.chatService.AddEmoji(context.Background(), chatID, req.Name, req.ImageURL)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidEmojiName):
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "name must be lowercase letters, digits and underscores",
+			})
+		case errors.Is(err, service.ErrEmojiExists):
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+				Error: "chat already has an emoji with this name",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to add emoji",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.ChatEmojiResponse{
+		Name:      created.Name,
+		ImageURL:  created.ImageURL,
+		CreatedAt: created.CreatedAt,
+	})
+}
+
+// RemoveEmoji removes a custom emoji. Reactions with it are kept.
+func (h *ChatHandler) RemoveEmoji(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	chatID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid chat id",
+		})
+	}
+
+	// Check if user is admin
+	isAdmin, err := h.chatService.IsUserAdminOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "only admins can remove emoji",
+		})
+	}
+
+	err = h.chatService.RemoveEmoji(context.Background(), chatID, c.Params("name"))
+	if err != nil {
+		if errors.Is(err, service.ErrEmojiNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+				Error: "emoji not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to remove emoji",
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// messagePreview shows the start of a message, e.g. the last one of a chat or
+// a quoted one. The sender must be loaded.
+func messagePreview(msg *ent.Message) *model.MessagePreviewResponse {
+	preview := &model.MessagePreviewResponse{
+		ID:        msg.ID,
+		Snippet:   messageSnippet(msg.Content),
+		CreatedAt: msg.CreatedAt,
+	}
+	if msg.Edges.Sender != nil {
+		preview.SenderID = msg.Edges.Sender.ID
+		preview.SenderUsername = msg.Edges.Sender.Username
+	}
+	return preview
+}
+
+// messageSnippet shortens a message to snippetLength characters.
+func messageSnippet(content string) string {
+	runes := []rune(content)
+	if len(runes) <= snippetLength {
+		return content
+	}
+	return string(runes[:snippetLength]) + "…"
+}
@@ -2,7 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log"
 
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
@@ -12,14 +15,16 @@ import (
 )
 
 type MessageHandler struct {
-	messageService *service.MessageService
-	chatService    *service.ChatService
+	messageService  *service.MessageService
+	chatService     *service.ChatService
+	reactionService *service.ReactionService
 }
 
-func NewMessageHandler(client *ent.Client) *MessageHandler {
+func NewMessageHandler(client *ent.Client, events *event.Bus) *MessageHandler {
 	return &MessageHandler{
-		messageService: service.NewMessageService(client),
-		chatService:    service.NewChatService(client),
+		messageService:  service.NewMessageService(client, events),
+		chatService:     service.NewChatService(client, events),
+		reactionService: service.NewReactionService(client, events),
 	}
 }
 
@@ -45,30 +50,27 @@ func (h *MessageHandler) SendMessage(c fiber.Ctx) error {
 	}
 
 	// Send message
-	newMessage, err := h.messageService.SendMessage(context.Background(), req.ChatID, userID, req.Content)
+	newMessage, err := h.messageService.SendMessage(context.Background(), req.ChatID, userID, req.Content, req.ReplyToID)
 	if err != nil {
+		if errors.Is(err, service.ErrMessageNotInChat) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "reply_to_id must be a message of the same chat",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
 			Error: "failed to send message",
 		})
 	}
 
-	senderID := userID
-	chatID := req.ChatID
-	if newMessage.Edges.Sender != nil {
-		senderID = newMessage.Edges.Sender.ID
-	}
-	if newMessage.Edges.Chat != nil {
-		chatID = newMessage.Edges.Chat.ID
+	// Reload with edges, so the response has the same shape as GetMessage
+	response, err := h.readBack(userID, newMessage.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to load message",
+		})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(model.MessageResponse{
-		ID:        newMessage.ID,
-		Content:   newMessage.Content,
-		SenderID:  senderID,
-		ChatID:    chatID,
-		CreatedAt: newMessage.CreatedAt,
-		UpdatedAt: newMessage.UpdatedAt,
-	})
+	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
 func (h *MessageHandler) GetMessage(c fiber.Ctx) error {
@@ -88,12 +90,7 @@ func (h *MessageHandler) GetMessage(c fiber.Ctx) error {
 		})
 	}
 
-	// Get IDs from edges
-	senderID := 0
 	chatID := 0
-	if msg.Edges.Sender != nil {
-		senderID = msg.Edges.Sender.ID
-	}
 	if msg.Edges.Chat != nil {
 		chatID = msg.Edges.Chat.ID
 	}
@@ -111,26 +108,14 @@ func (h *MessageHandler) GetMessage(c fiber.Ctx) error {
 		})
 	}
 
-	response := model.MessageResponse{
-		ID:        msg.ID,
-		Content:   msg.Content,
-		SenderID:  senderID,
-		ChatID:    chatID,
-		CreatedAt: msg.CreatedAt,
-		UpdatedAt: msg.UpdatedAt,
-	}
-
-	if msg.Edges.Sender != nil {
-		response.Sender = &model.UserProfile{
-			ID:          msg.Edges.Sender.ID,
-			Username:    msg.Edges.Sender.Username,
-			DisplayName: msg.Edges.Sender.DisplayName,
-			CreatedAt:   msg.Edges.Sender.CreatedAt,
-			LastSeen:    msg.Edges.Sender.LastSeen,
-		}
+	responses := []model.MessageResponse{messageResponse(msg)}
+	if err := h.addReactions(userID, responses); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list reactions",
+		})
 	}
 
-	return c.JSON(response)
+	return c.JSON(responses[0])
 }
 
 func (h *MessageHandler) ListMessages(c fiber.Ctx) error {
@@ -165,37 +150,19 @@ func (h *MessageHandler) ListMessages(c fiber.Ctx) error {
 		})
 	}
 
+	// Fetching the messages delivers them
+	if err := h.messageService.MarkFetched(context.Background(), userID, messages); err != nil {
+		log.Printf("Error recording delivery of messages in chat %d to user %d: %v", chatID, userID, err)
+	}
+
 	messageResponses := make([]model.MessageResponse, 0, len(messages))
 	for _, msg := range messages {
-		senderID := 0
-		chatID := 0
-		if msg.Edges.Sender != nil {
-			senderID = msg.Edges.Sender.ID
-		}
-		if msg.Edges.Chat != nil {
-			chatID = msg.Edges.Chat.ID
-		}
-
-		response := model.MessageResponse{
-			ID:        msg.ID,
-			Content:   msg.Content,
-			SenderID:  senderID,
-			ChatID:    chatID,
-			CreatedAt: msg.CreatedAt,
-			UpdatedAt: msg.UpdatedAt,
-		}
-
-		if msg.Edges.Sender != nil {
-			response.Sender = &model.UserProfile{
-				ID:          msg.Edges.Sender.ID,
-				Username:    msg.Edges.Sender.Username,
-				DisplayName: msg.Edges.Sender.DisplayName,
-				CreatedAt:   msg.Edges.Sender.CreatedAt,
-				LastSeen:    msg.Edges.Sender.LastSeen,
-			}
-		}
-
-		messageResponses = append(messageResponses, response)
+		messageResponses = append(messageResponses, messageResponse(msg))
+	}
+	if err := h.addReactions(userID, messageResponses); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list reactions",
+		})
 	}
 
 	return c.JSON(messageResponses)
@@ -229,33 +196,22 @@ func (h *MessageHandler) UpdateMessage(c fiber.Ctx) error {
 	}
 
 	// Update message
-	updatedMessage, err := h.messageService.UpdateMessage(context.Background(), messageID, req.Content)
+	_, err = h.messageService.UpdateMessage(context.Background(), messageID, req.Content)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
 			Error: "failed to update message",
 		})
 	}
 
-	// Reload with edges
-	updatedMessage, _ = h.messageService.GetMessageByID(context.Background(), messageID)
-
-	senderID := userID
-	chatID := 0
-	if updatedMessage.Edges.Sender != nil {
-		senderID = updatedMessage.Edges.Sender.ID
-	}
-	if updatedMessage.Edges.Chat != nil {
-		chatID = updatedMessage.Edges.Chat.ID
+	// Reload with edges, so the response has the same shape as GetMessage
+	response, err := h.readBack(userID, messageID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to load message",
+		})
 	}
 
-	return c.JSON(model.MessageResponse{
-		ID:        updatedMessage.ID,
-		Content:   updatedMessage.Content,
-		SenderID:  senderID,
-		ChatID:    chatID,
-		CreatedAt: updatedMessage.CreatedAt,
-		UpdatedAt: updatedMessage.UpdatedAt,
-	})
+	return c.JSON(response)
 }
 
 func (h *MessageHandler) DeleteMessage(c fiber.Ctx) error {
@@ -290,3 +246,199 @@ func (h *MessageHandler) DeleteMessage(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
+
+// GetSeenBy returns the members who read a message, most recent first.
+// Members who hide their read receipts are not listed.
+func (h *MessageHandler) GetSeenBy(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	messageID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid message id",
+		})
+	}
+
+	msg, err := h.messageService.GetMessageByID(context.Background(), messageID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "message not found",
+		})
+	}
+
+	chatID := 0
+	if msg.Edges.Chat != nil {
+		chatID = msg.Edges.Chat.ID
+	}
+
+	// Check if user is a member of the chat
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	readers, err := h.messageService.ListReaders(context.Background(), msg)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list readers",
+		})
+	}
+
+	responses := make([]model.MessageReaderResponse, 0, len(readers))
+	for _, reader := range readers {
+		if reader.Edges.User == nil || reader.LastReadAt == nil {
+			continue
+		}
+		responses = append(responses, model.MessageReaderResponse{
+			UserID:   reader.Edges.User.ID,
+			Username: reader.Edges.User.Username,
+			ReadAt:   *reader.LastReadAt,
+		})
+	}
+
+	return c.JSON(responses)
+}
+
+// ListReplies returns a message with its replies, oldest first.
+func (h *MessageHandler) ListReplies(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	messageID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid message id",
+		})
+	}
+
+	limit := utils.QueryInt(c, "limit", 50)
+	offset := utils.QueryInt(c, "offset", 0)
+
+	root, err := h.messageService.GetMessageByID(context.Background(), messageID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "message not found",
+		})
+	}
+
+	chatID := 0
+	if root.Edges.Chat != nil {
+		chatID = root.Edges.Chat.ID
+	}
+
+	// Check if user is a member of the chat
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	replies, err := h.messageService.ListReplies(context.Background(), messageID, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list replies",
+		})
+	}
+
+	// Fetching the replies delivers them
+	if err := h.messageService.MarkFetched(context.Background(), userID, replies); err != nil {
+		log.Printf("Error recording delivery of replies to message %d to user %d: %v", messageID, userID, err)
+	}
+
+	thread := make([]model.MessageResponse, 0, len(replies)+1)
+	thread = append(thread, messageResponse(root))
+	for _, reply := range replies {
+		thread = append(thread, messageResponse(reply))
+	}
+	if err := h.addReactions(userID, thread); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list reactions",
+		})
+	}
+
+	response := model.ThreadResponse{
+		Root:    thread[0],
+		Replies: thread[1:],
+	}
+
+	return c.JSON(response)
+}
+
+// readBack loads a message the user just wrote and converts it like
+// GetMessage does.
+func (h *MessageHandler) readBack(userID, messageID int) (model.MessageResponse, error) {
+	msg, err := h.messageService.GetMessageByID(context.Background(), messageID)
+	if err != nil {
+		return model.MessageResponse{}, err
+	}
+
+	responses := []model.MessageResponse{messageResponse(msg)}
+	if err := h.addReactions(userID, responses); err != nil {
+		return model.MessageResponse{}, err
+	}
+	return responses[0], nil
+}
+
+// messageResponse converts a message read back from the chat, with its edges,
+// delivery counts and thread summary. Reactions are added by addReactions.
+func messageResponse(msg *ent.Message) model.MessageResponse {
+	response := model.MessageResponse{
+		ID:        msg.ID,
+		Content:   msg.Content,
+		IsEdited:  msg.IsEdited,
+		CreatedAt: msg.CreatedAt,
+		UpdatedAt: msg.UpdatedAt,
+		Delivery:  messageDelivery(msg),
+	}
+	response.ReplyCount, response.LastReplyAt = service.MessageReplies(msg)
+
+	if msg.Edges.Chat != nil {
+		response.ChatID = msg.Edges.Chat.ID
+	}
+	if msg.Edges.Sender != nil {
+		response.SenderID = msg.Edges.Sender.ID
+		response.Sender = &model.UserProfile{
+			ID:          msg.Edges.Sender.ID,
+			Username:    msg.Edges.Sender.Username,
+			DisplayName: msg.Edges.Sender.DisplayName,
+			CreatedAt:   msg.Edges.Sender.CreatedAt,
+			LastSeen:    publicLastSeen(msg.Edges.Sender),
+		}
+	}
+	if replyTo := msg.Edges.ReplyTo; replyTo != nil {
+		response.ReplyToID = &replyTo.ID
+		response.ReplyTo = messagePreview(replyTo)
+	}
+
+	return response
+}
+
+// messageDelivery summarizes the delivery counts read with a message.
+func messageDelivery(msg *ent.Message) *model.MessageDeliveryResponse {
+	counts := service.MessageDeliveryCounts(msg)
+	status := model.MessageStatusSent
+	switch {
+	case counts.Recipients == 0:
+		// Nobody else is in the chat
+	case counts.Read == counts.Recipients:
+		status = model.MessageStatusRead
+	case counts.Delivered == counts.Recipients:
+		status = model.MessageStatusDelivered
+	}
+	return &model.MessageDeliveryResponse{
+		Status:         status,
+		RecipientCount: counts.Recipients,
+		DeliveredCount: counts.Delivered,
+		ReadCount:      counts.Read,
+	}
+}
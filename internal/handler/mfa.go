@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/gofiber/fiber/v3"
+)
+
+type MFAHandler struct {
+	mfaService *service.MFAService
+}
+
+func NewMFAHandler(mfaService *service.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+func (h *MFAHandler) SetupTOTP(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	enrollment, err := h.mfaService.BeginEnrollment(context.Background(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrMFAAlreadyEnabled) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+				Error: "two-factor authentication is already enabled",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to start two-factor enrollment",
+		})
+	}
+
+	return c.JSON(model.TOTPSetupResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+func (h *MFAHandler) ConfirmTOTP(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	req := new(model.MFACodeRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	codes, err := h.mfaService.ConfirmEnrollment(context.Background(), userID, req.Code)
+	if err != nil {
+		return h.respondMFAError(c, err, "failed to enable two-factor authentication")
+	}
+
+	return c.JSON(model.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+func (h *MFAHandler) DisableTOTP(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	req := new(model.DisableMFARequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	err := h.mfaService.Disable(context.Background(), userID, req.Password, req.Code)
+	if err != nil {
+		return h.respondMFAError(c, err, "failed to disable two-factor authentication")
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+func (h *MFAHandler) RegenerateRecoveryCodes(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	req := new(model.MFACodeRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(context.Background(), userID, req.Code)
+	if err != nil {
+		return h.respondMFAError(c, err, "failed to regenerate recovery codes")
+	}
+
+	return c.JSON(model.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+func (h *MFAHandler) respondMFAError(c fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrMFAAlreadyEnabled):
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+			Error: "two-factor authentication is already enabled",
+		})
+	case errors.Is(err, service.ErrMFANotEnrolled):
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "start two-factor enrollment first",
+		})
+	case errors.Is(err, service.ErrMFANotEnabled):
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "two-factor authentication is not enabled",
+		})
+	case errors.Is(err, service.ErrInvalidMFACode):
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+			Error: "invalid two-factor code",
+		})
+	case errors.Is(err, service.ErrInvalidPassword):
+		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+			Error: "invalid password",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+		Error: fallback,
+	})
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/utils"
+	"github.com/gofiber/fiber/v3"
+)
+
+// oidcStateCookie keeps the sealed flow state between the redirect to the
+// provider and the callback, binding the flow to the browser that started it.
+const oidcStateCookie = "chatapp_oidc_state"
+
+type OIDCHandler struct {
+	oidcService         *service.OIDCService
+	sessionService      *service.SessionService
+	mfaService          *service.MFAService
+	frontendRedirectURL string
+	secureCookies       bool
+}
+
+func NewOIDCHandler(oidcService *service.OIDCService, sessionService *service.SessionService, mfaService *service.MFAService, cfg config.OIDCConfig, secureCookies bool) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService:         oidcService,
+		sessionService:      sessionService,
+		mfaService:          mfaService,
+		frontendRedirectURL: cfg.FrontendRedirectURL,
+		secureCookies:       secureCookies,
+	}
+}
+
+func (h *OIDCHandler) ListProviders(c fiber.Ctx) error {
+	providers := h.oidcService.Providers()
+
+	providerResponses := make([]model.OIDCProviderResponse, len(providers))
+	for i, p := range providers {
+		providerResponses[i] = model.OIDCProviderResponse{
+			Name:        p.Name,
+			DisplayName: p.DisplayName,
+		}
+	}
+
+	return c.JSON(providerResponses)
+}
+
+// Login redirects the browser to the identity provider. With ?reauth=true the
+// provider asks the user to log in again, e.g. before deleting an account
+// that has no password.
+func (h *OIDCHandler) Login(c fiber.Ctx) error {
+	reauth := fiber.Query[bool](c, "reauth", false)
+
+	authURL, stateToken, err := h.oidcService.Begin(context.Background(), c.Params("provider"), 0, reauth)
+	if err != nil {
+		return h.respondOIDCError(c, err, "failed to start single sign-on")
+	}
+
+	h.setStateCookie(c, stateToken)
+	return c.Redirect().Status(fiber.StatusFound).To(authURL)
+}
+
+// Link starts a flow that links an identity at the provider to the current
+// user. The client must open the returned URL in the same browser.
+func (h *OIDCHandler) Link(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	authURL, stateToken, err := h.oidcService.Begin(context.Background(), c.Params("provider"), userID, false)
+	if err != nil {
+		return h.respondOIDCError(c, err, "failed to start identity linking")
+	}
+
+	h.setStateCookie(c, stateToken)
+	return c.JSON(model.OIDCLinkResponse{
+		AuthorizationURL: authURL,
+	})
+}
+
+// Callback completes a flow started by Login or Link.
+func (h *OIDCHandler) Callback(c fiber.Ctx) error {
+	stateToken := c.Cookies(oidcStateCookie)
+	h.clearStateCookie(c)
+
+	if providerError := c.Query("error"); providerError != "" {
+		log.Printf("OIDC provider %q returned error %q: %s", c.Params("provider"), providerError, c.Query("error_description"))
+		return h.respondCallback(c, fiber.StatusUnauthorized, url.Values{"error": {"login was cancelled or denied"}}, model.ErrorResponse{
+			Error: "login was cancelled or denied",
+		})
+	}
+
+	login, err := h.oidcService.Complete(context.Background(), c.Params("provider"), stateToken, c.Query("state"), c.Query("code"))
+	if err != nil {
+		status, message := oidcErrorStatus(err, "single sign-on failed")
+		if status == fiber.StatusInternalServerError || errors.Is(err, service.ErrOIDCLoginFailed) {
+			log.Printf("OIDC login with %q failed: %v", c.Params("provider"), err)
+		}
+		return h.respondCallback(c, status, url.Values{"error": {message}}, model.ErrorResponse{
+			Error: message,
+		})
+	}
+
+	if login.Linked {
+		return h.respondCallback(c, fiber.StatusOK, url.Values{"linked": {c.Params("provider")}}, fiber.Map{
+			"linked":   true,
+			"provider": c.Params("provider"),
+		})
+	}
+
+	u := login.User
+
+	// The provider replaces the password, not the second factor
+	if u.TotpEnabled {
+		mfaToken, expiresAt, err := h.mfaService.CreatePendingToken(context.Background(), u.ID)
+		if err != nil {
+			return h.respondCallback(c, fiber.StatusInternalServerError, url.Values{"error": {"failed to generate token"}}, model.ErrorResponse{
+				Error: "failed to generate token",
+			})
+		}
+
+		return h.respondCallback(c, fiber.StatusOK, url.Values{
+			"mfa_required": {"true"},
+			"mfa_token":    {mfaToken},
+			"expires_at":   {expiresAt.Format(time.RFC3339)},
+		}, model.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresAt:   expiresAt,
+		})
+	}
+
+	meta := sessionMetadata(c, "")
+	meta.AuthenticatedAt = login.AuthenticatedAt
+	tokens, err := h.sessionService.StartSession(context.Background(), u.ID, u.Username, meta)
+	if err != nil {
+		return h.respondCallback(c, fiber.StatusInternalServerError, url.Values{"error": {"failed to generate token"}}, model.ErrorResponse{
+			Error: "failed to generate token",
+		})
+	}
+
+	return h.respondCallback(c, fiber.StatusOK, url.Values{
+		"token":         {tokens.AccessToken},
+		"refresh_token": {tokens.RefreshToken},
+		"expires_at":    {tokens.ExpiresAt.Format(time.RFC3339)},
+		"provisioned":   {strconv.FormatBool(login.Provisioned)},
+	}, model.AuthResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+		User:         ownUserProfile(u),
+	})
+}
+
+func (h *OIDCHandler) ListIdentities(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	identities, err := h.oidcService.ListIdentities(context.Background(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list identities",
+		})
+	}
+
+	identityResponses := make([]model.IdentityResponse, len(identities))
+	for i, ident := range identities {
+		identityResponses[i] = model.IdentityResponse{
+			ID:          ident.ID,
+			Provider:    ident.Provider,
+			Email:       ident.Email,
+			CreatedAt:   ident.CreatedAt,
+			LastLoginAt: ident.LastLoginAt,
+		}
+	}
+
+	return c.JSON(identityResponses)
+}
+
+func (h *OIDCHandler) UnlinkIdentity(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	identityID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid identity id",
+		})
+	}
+
+	if err := h.oidcService.Unlink(context.Background(), userID, identityID); err != nil {
+		return h.respondOIDCError(c, err, "failed to unlink identity")
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// respondCallback redirects to the frontend with values in the URL fragment,
+// which browsers never send to servers, or responds with JSON when no
+// frontend is configured.
+func (h *OIDCHandler) respondCallback(c fiber.Ctx, status int, values url.Values, body interface{}) error {
+	if h.frontendRedirectURL == "" {
+		return c.Status(status).JSON(body)
+	}
+	return c.Redirect().Status(fiber.StatusFound).To(h.frontendRedirectURL + "#" + values.Encode())
+}
+
+func (h *OIDCHandler) setStateCookie(c fiber.Ctx, stateToken string) {
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    stateToken,
+		Path:     "/api/v1/auth/oidc",
+		MaxAge:   int(h.oidcService.StateTTL().Seconds()),
+		Secure:   h.secureCookies,
+		HTTPOnly: true,
+		// Lax, so the cookie is sent on the top-level redirect back from the provider
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func (h *OIDCHandler) clearStateCookie(c fiber.Ctx) {
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/api/v1/auth/oidc",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		Secure:   h.secureCookies,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func (h *OIDCHandler) respondOIDCError(c fiber.Ctx, err error, fallback string) error {
+	status, message := oidcErrorStatus(err, fallback)
+	if status == fiber.StatusInternalServerError {
+		log.Printf("OIDC error: %v", err)
+	}
+	return c.Status(status).JSON(model.ErrorResponse{
+		Error: message,
+	})
+}
+
+func oidcErrorStatus(err error, fallback string) (int, string) {
+	switch {
+	case errors.Is(err, service.ErrUnknownProvider):
+		return fiber.StatusNotFound, "unknown identity provider"
+	case errors.Is(err, service.ErrInvalidOIDCState):
+		return fiber.StatusBadRequest, "invalid or expired login state, start again"
+	case errors.Is(err, service.ErrOIDCLoginFailed):
+		return fiber.StatusUnauthorized, "identity provider login failed"
+	case errors.Is(err, service.ErrIdentityNotLinked):
+		return fiber.StatusForbidden, "no account is linked to this identity"
+	case errors.Is(err, service.ErrIdentityLinkedElsewhere):
+		return fiber.StatusConflict, "identity is already linked to another account"
+	case errors.Is(err, service.ErrIdentityNotFound):
+		return fiber.StatusNotFound, "identity not found"
+	case errors.Is(err, service.ErrLastLoginMethod):
+		return fiber.StatusConflict, "set a password before removing your only identity"
+	}
+	return fiber.StatusInternalServerError, fallback
+}
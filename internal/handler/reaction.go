@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/utils"
+	"github.com/gofiber/fiber/v3"
+)
+
+// AddReaction reacts to a message with a Unicode emoji or a custom emoji of
+// the chat as :name:, and returns the reactions to the message.
+func (h *MessageHandler) AddReaction(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	messageID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid message id",
+		})
+	}
+
+	req := new(model.ReactionRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	msg, err := h.messageService.GetMessageByID(context.Background(), messageID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "message not found",
+		})
+	}
+
+	chatID := 0
+	if msg.Edges.Chat != nil {
+		chatID = msg.Edges.Chat.ID
+	}
+
+	// Check if user is a member of the chat
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	err = h.reactionService.AddReaction(context.Background(), msg, userID, req.Emoji)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidEmoji) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "emoji must be a single emoji or a custom emoji of this chat",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to add reaction",
+		})
+	}
+
+	return h.respondReactions(c, userID, messageID)
+}
+
+// RemoveReaction takes back the reaction of the current user given as
+// ?emoji=, and returns the reactions to the message.
+func (h *MessageHandler) RemoveReaction(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	messageID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid message id",
+		})
+	}
+
+	value := c.Query("emoji")
+	if value == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "emoji is required",
+		})
+	}
+
+	msg, err := h.messageService.GetMessageByID(context.Background(), messageID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "message not found",
+		})
+	}
+
+	chatID := 0
+	if msg.Edges.Chat != nil {
+		chatID = msg.Edges.Chat.ID
+	}
+
+	// Check if user is a member of the chat
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	err = h.reactionService.RemoveReaction(context.Background(), msg, userID, value)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to remove reaction",
+		})
+	}
+
+	return h.respondReactions(c, userID, messageID)
+}
+
+// ListReactions returns who reacted to a message, oldest first. ?emoji= only
+// lists the reactions with that emoji.
+func (h *MessageHandler) ListReactions(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	messageID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid message id",
+		})
+	}
+
+	msg, err := h.messageService.GetMessageByID(context.Background(), messageID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "message not found",
+		})
+	}
+
+	chatID := 0
+	if msg.Edges.Chat != nil {
+		chatID = msg.Edges.Chat.ID
+	}
+
+	// Check if user is a member of the chat
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
+	}
+
+	reactions, err := h.reactionService.ListReactions(context.Background(), messageID, c.Query("emoji"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list reactions",
+		})
+	}
+
+	responses := make([]model.ReactionResponse, 0, len(reactions))
+	for _, r := range reactions {
+		if r.Edges.User == nil {
+			continue
+		}
+		responses = append(responses, model.ReactionResponse{
+			Emoji:     r.Emoji,
+			UserID:    r.Edges.User.ID,
+			Username:  r.Edges.User.Username,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return c.JSON(responses)
+}
+
+// respondReactions responds with the reactions to a message as the user sees
+// them.
+func (h *MessageHandler) respondReactions(c fiber.Ctx, userID, messageID int) error {
+	summaries, err := h.reactionService.Summaries(context.Background(), userID, []int{messageID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to list reactions",
+		})
+	}
+
+	return c.JSON(reactionSummaries(summaries[messageID]))
+}
+
+// addReactions fills in the reactions to messages read back from the chat,
+// as the user sees them.
+func (h *MessageHandler) addReactions(userID int, responses []model.MessageResponse) error {
+	ids := make([]int, 0, len(responses))
+	for _, response := range responses {
+		ids = append(ids, response.ID)
+	}
+
+	summaries, err := h.reactionService.Summaries(context.Background(), userID, ids)
+	if err != nil {
+		return err
+	}
+	for i := range responses {
+		if s := summaries[responses[i].ID]; len(s) > 0 {
+			responses[i].Reactions = reactionSummaries(s)
+		}
+	}
+	return nil
+}
+
+func reactionSummaries(summaries []service.ReactionSummary) []model.ReactionSummaryResponse {
+	responses := make([]model.ReactionSummaryResponse, 0, len(summaries))
+	for _, s := range summaries {
+		responses = append(responses, model.ReactionSummaryResponse{
+			Emoji:       s.Emoji,
+			Count:       s.Count,
+			ReactedByMe: s.ReactedByMe,
+		})
+	}
+	return responses
+}
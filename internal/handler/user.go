@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log"
 	"time"
 
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
 	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/utils"
@@ -14,12 +17,24 @@ import (
 )
 
 type UserHandler struct {
-	userService *service.UserService
+	userService    *service.UserService
+	sessionService *service.SessionService
+	accountService *service.AccountService
+	mfaService     *service.MFAService
+	auditService   *service.AuditService
+	loginGuard     *auth.LoginGuard
+	reauthWindow   time.Duration
 }
 
-func NewUserHandler(client *ent.Client, authService *auth.Service) *UserHandler {
+func NewUserHandler(client *ent.Client, authService *auth.Service, sessionService *service.SessionService, accountService *service.AccountService, mfaService *service.MFAService, loginGuard *auth.LoginGuard, reauthWindow time.Duration) *UserHandler {
 	return &UserHandler{
-		userService: service.NewUserService(client, authService),
+		userService:    service.NewUserService(client, authService),
+		sessionService: sessionService,
+		accountService: accountService,
+		mfaService:     mfaService,
+		auditService:   service.NewAuditService(client),
+		loginGuard:     loginGuard,
+		reauthWindow:   reauthWindow,
 	}
 }
 
@@ -40,8 +55,9 @@ func (h *UserHandler) ListUsers(c fiber.Ctx) error {
 			ID:          u.ID,
 			Username:    u.Username,
 			DisplayName: u.DisplayName,
+			IsBot:       u.Type == user.TypeBot,
 			CreatedAt:   u.CreatedAt,
-			LastSeen:    u.LastSeen,
+			LastSeen:    publicLastSeen(u),
 		}
 	}
 
@@ -67,8 +83,9 @@ func (h *UserHandler) GetUser(c fiber.Ctx) error {
 		ID:          u.ID,
 		Username:    u.Username,
 		DisplayName: u.DisplayName,
+		IsBot:       u.Type == user.TypeBot,
 		CreatedAt:   u.CreatedAt,
-		LastSeen:    u.LastSeen,
+		LastSeen:    publicLastSeen(u),
 	})
 }
 
@@ -92,28 +109,147 @@ func (h *UserHandler) UpdateUser(c fiber.Ctx) error {
 		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
 	}
 
-	if req.Password != "" && len(req.Password) < 6 {
+	// The password is only changed through the endpoint that checks the
+	// current one
+	if req.Password != "" {
 		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
-			Error: "password must be at least 6 characters",
+			Error: "password cannot be updated here, use POST /api/v1/users/me/password",
 		})
 	}
 
-	u, err := h.userService.UpdateUser(context.Background(), id, req.DisplayName, req.Password)
+	current, err := h.userService.GetUserByID(context.Background(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "user not found",
+		})
+	}
+
+	// Only a different address needs checking and verifying again
+	email := service.NormalizeEmail(req.Email)
+	if current.Email != nil && *current.Email == email {
+		email = ""
+	}
+	if email != "" {
+		exists, err := h.userService.EmailExists(context.Background(), email)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+				Error: "failed to check email existence",
+			})
+		}
+		if exists {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse{
+				Error: "email already in use",
+			})
+		}
+	}
+
+	u, err := h.userService.UpdateUser(context.Background(), id, service.UserUpdate{
+		DisplayName:      req.DisplayName,
+		Email:            email,
+		HideLastSeen:     req.HideLastSeen,
+		HideReadReceipts: req.HideReadReceipts,
+	})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
 			Error: "failed to update user",
 		})
 	}
 
-	return c.JSON(model.UserProfile{
-		ID:          u.ID,
-		Username:    u.Username,
-		DisplayName: u.DisplayName,
-		CreatedAt:   u.CreatedAt,
-		LastSeen:    u.LastSeen,
+	if email != "" {
+		if err := h.accountService.SendVerificationEmail(context.Background(), u.ID); err != nil {
+			log.Printf("Error sending verification email to user %d: %v", u.ID, err)
+		}
+	}
+
+	return c.JSON(ownUserProfile(u))
+}
+
+// ChangePassword sets a new password after checking the current one and logs
+// out every other device.
+func (h *UserHandler) ChangePassword(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	sessionID := c.Locals("session_id").(int)
+	username := c.Locals("username").(string)
+
+	req := new(model.ChangePasswordRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	// Guessing the current password is throttled like logging in
+	retryAfter, err := h.loginGuard.Check(context.Background(), username, c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check login attempts",
+		})
+	}
+	if retryAfter > 0 {
+		return respondTooManyAttempts(c, retryAfter)
+	}
+
+	u, err := h.userService.GetUserByID(context.Background(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "user not found",
+		})
+	}
+
+	// Without a password, e.g. after single sign-on, the user proves who they
+	// are with a two-factor code or a recent login instead
+	reauthenticated := false
+	if !auth.HasUsablePassword(u.Password) {
+		if u.TotpEnabled {
+			if ok, err := h.verifyCode(c, u, req.Code); !ok {
+				return err
+			}
+		} else if ok, err := h.requireRecentLogin(c); !ok {
+			return err
+		}
+		reauthenticated = true
+	}
+
+	err = h.userService.ChangePassword(context.Background(), userID, req.CurrentPassword, req.NewPassword, reauthenticated)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPassword) {
+			recordGuardFailure(c, h.loginGuard, h.auditService, username, userID)
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "invalid current password",
+			})
+		}
+		if policyErr, ok := asPasswordPolicyError(err); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: policyErr.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to change password",
+		})
+	}
+	if err := h.loginGuard.RecordSuccess(context.Background(), username); err != nil {
+		log.Printf("Error resetting failed logins for %q: %v", username, err)
+	}
+
+	if err := h.sessionService.RevokeOtherSessions(context.Background(), userID, sessionID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to revoke other sessions",
+		})
+	}
+
+	h.recordAudit(c, service.AuditEntry{
+		Action:    service.AuditPasswordChanged,
+		UserID:    userID,
+		IPAddress: c.IP(),
+		Details: map[string]interface{}{
+			"session_id": sessionID,
+		},
 	})
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
 }
 
+// DeleteUser deletes the account after re-authenticating the user with their
+// password and, if enabled, a two-factor code. Users without a password and
+// without 2FA must have logged in recently.
 func (h *UserHandler) DeleteUser(c fiber.Ctx) error {
 	userID := c.Locals("user_id").(int)
 	id, err := utils.ParamsInt(c, "id")
@@ -129,6 +265,59 @@ func (h *UserHandler) DeleteUser(c fiber.Ctx) error {
 		})
 	}
 
+	req := new(model.DeleteUserRequest)
+	if err := f.ParseRequestBody(c, req); err != nil {
+		return f.RespondError(c, fiber.StatusBadRequest, err.Message, err.Errors)
+	}
+
+	u, err := h.userService.GetUserByID(context.Background(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse{
+			Error: "user not found",
+		})
+	}
+
+	// Wrong passwords and codes count against the same limits as on login
+	retryAfter, err := h.loginGuard.Check(context.Background(), u.Username, c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check login attempts",
+		})
+	}
+	if retryAfter > 0 {
+		return respondTooManyAttempts(c, retryAfter)
+	}
+
+	if auth.HasUsablePassword(u.Password) {
+		if _, err := h.userService.VerifyPassword(u.Password, req.Password); err != nil {
+			recordGuardFailure(c, h.loginGuard, h.auditService, u.Username, u.ID)
+			return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "invalid password",
+			})
+		}
+	}
+
+	if u.TotpEnabled {
+		if ok, err := h.verifyCode(c, u, req.Code); !ok {
+			return err
+		}
+	} else if !auth.HasUsablePassword(u.Password) {
+		// Accounts provisioned through single sign-on have nothing to check
+		// but the time of the login
+		if ok, err := h.requireRecentLogin(c); !ok {
+			return err
+		}
+	}
+
+	// The sessions are removed together with the user, so look them up
+	// first to close their live connections afterwards
+	sessionIDs, err := h.sessionService.UnrevokedSessionIDs(context.Background(), id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to find sessions",
+		})
+	}
+
 	err = h.userService.DeleteUser(context.Background(), id)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
@@ -136,21 +325,100 @@ func (h *UserHandler) DeleteUser(c fiber.Ctx) error {
 		})
 	}
 
+	h.sessionService.SessionsEnded(sessionIDs)
+	if err := h.loginGuard.RecordSuccess(context.Background(), u.Username); err != nil {
+		log.Printf("Error resetting failed logins for %q: %v", u.Username, err)
+	}
+
+	// The audit log outlives the user, so the entry is not linked to it and
+	// keeps the id and username in the details
+	h.recordAudit(c, service.AuditEntry{
+		Action:    service.AuditAccountDeleted,
+		IPAddress: c.IP(),
+		Details: map[string]interface{}{
+			"user_id":  id,
+			"username": u.Username,
+		},
+	})
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
 
-func (h *UserHandler) UpdateLastSeen(c fiber.Ctx) error {
-	userID := c.Locals("user_id").(int)
+// verifyCode checks the two-factor code of a user who enabled 2FA. It responds
+// and returns false when the code is missing or wrong.
+func (h *UserHandler) verifyCode(c fiber.Ctx, u *ent.User, code string) (bool, error) {
+	if code == "" {
+		return false, c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "two-factor code is required",
+		})
+	}
+	if err := h.mfaService.VerifyCode(context.Background(), u, code); err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			recordGuardFailure(c, h.loginGuard, h.auditService, u.Username, u.ID)
+			return false, c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse{
+				Error: "invalid two-factor code",
+			})
+		}
+		return false, c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to verify two-factor code",
+		})
+	}
+	return true, nil
+}
+
+// requireRecentLogin checks that the user logged in to the current session
+// within the reauth window; a stolen access token alone is not enough. It
+// responds and returns false otherwise.
+func (h *UserHandler) requireRecentLogin(c fiber.Ctx) (bool, error) {
+	sessionID := c.Locals("session_id").(int)
 
-	err := h.userService.UpdateLastSeen(context.Background(), userID)
+	recent, err := h.sessionService.RecentlyAuthenticated(context.Background(), sessionID, h.reauthWindow)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
-			Error: "failed to update last seen",
+		return false, c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check session",
+		})
+	}
+	if !recent {
+		return false, c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "log in again to confirm it is you",
 		})
 	}
+	return true, nil
+}
 
-	return c.JSON(fiber.Map{
-		"success":   true,
-		"last_seen": time.Now(),
-	})
+// recordAudit records an audit event. Errors are only logged so the client
+// response is never affected.
+func (h *UserHandler) recordAudit(c fiber.Ctx, entry service.AuditEntry) {
+	if err := h.auditService.Record(context.Background(), entry); err != nil {
+		log.Printf("Error recording %s audit log: %v", entry.Action, err)
+	}
+}
+
+// ownUserProfile builds the profile shown to the user themselves, which
+// includes private fields such as the email address.
+func ownUserProfile(u *ent.User) model.UserProfile {
+	emailVerified := u.EmailVerifiedAt != nil
+	profile := model.UserProfile{
+		ID:               u.ID,
+		Username:         u.Username,
+		DisplayName:      u.DisplayName,
+		IsBot:            u.Type == user.TypeBot,
+		CreatedAt:        u.CreatedAt,
+		LastSeen:         u.LastSeen,
+		Email:            u.Email,
+		HideLastSeen:     &u.HideLastSeen,
+		HideReadReceipts: &u.HideReadReceipts,
+	}
+	if u.Email != nil {
+		profile.EmailVerified = &emailVerified
+	}
+	return profile
+}
+
+// publicLastSeen returns when the user was last seen, unless they hide it.
+func publicLastSeen(u *ent.User) *time.Time {
+	if u.HideLastSeen {
+		return nil
+	}
+	return u.LastSeen
 }
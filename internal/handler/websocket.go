@@ -1,42 +1,71 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"strconv"
+	"strings"
 
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/hub"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/utils"
 	"github.com/fasthttp/websocket"
 	"github.com/gofiber/fiber/v3"
 	"github.com/valyala/fasthttp"
 )
 
+const (
+	// wsSubprotocol is the subprotocol the server selects, as "chatapp.v<N>"
+	// or plain "chatapp". Clients that authenticate with the
+	// Sec-WebSocket-Protocol header offer it together with "bearer.<access token>".
+	wsSubprotocol    = "chatapp"
+	wsBearerProtocol = "bearer."
+)
+
+var (
+	errMissingCredentials = errors.New("missing ticket")
+	errInvalidToken       = errors.New("invalid or expired token")
+	errSessionRevoked     = errors.New("session has been revoked")
+)
+
 type WebSocketHandler struct {
-	authService    *auth.Service
-	userService    *service.UserService
-	chatService    *service.ChatService
-	messageService *service.MessageService
-	clients        map[int]*websocket.Conn // userID -> connection
-	clientsMu      sync.RWMutex
-	chatRooms      map[int]map[int]bool // chatID -> map[userID]bool
-	roomsMu        sync.RWMutex
-	upgrader       websocket.FastHTTPUpgrader
-}
-
-func NewWebSocketHandler(client *ent.Client, authService *auth.Service) *WebSocketHandler {
+	authService     *auth.Service
+	sessionService  *service.SessionService
+	tickets         *auth.TicketIssuer
+	eventLog        *service.EventLogService
+	deliveries      *service.DeliveryQueue
+	allowQueryToken bool
+	userService     *service.UserService
+	chatService     *service.ChatService
+	messageService  *service.MessageService
+	hub             *hub.Hub
+	upgrader        websocket.FastHTTPUpgrader
+}
+
+func NewWebSocketHandler(client *ent.Client, authService *auth.Service, sessionService *service.SessionService, tickets *auth.TicketIssuer, connections *hub.Hub, eventLog *service.EventLogService, deliveries *service.DeliveryQueue, events *event.Bus, cfg config.WebSocketConfig) *WebSocketHandler {
 	return &WebSocketHandler{
-		authService:    authService,
-		userService:    service.NewUserService(client, authService),
-		chatService:    service.NewChatService(client),
-		messageService: service.NewMessageService(client),
-		clients:        make(map[int]*websocket.Conn),
-		chatRooms:      make(map[int]map[int]bool),
+		authService:     authService,
+		sessionService:  sessionService,
+		tickets:         tickets,
+		eventLog:        eventLog,
+		deliveries:      deliveries,
+		allowQueryToken: cfg.AllowQueryToken,
+		userService:     service.NewUserService(client, authService),
+		chatService:     service.NewChatService(client, events),
+		messageService:  service.NewMessageService(client, events),
+		hub:             connections,
 		upgrader: websocket.FastHTTPUpgrader{
+			Subprotocols: wsSubprotocols(),
 			CheckOrigin: func(ctx *fasthttp.RequestCtx) bool {
 				return true // Allow all origins in development
 			},
@@ -44,76 +73,87 @@ func NewWebSocketHandler(client *ent.Client, authService *auth.Service) *WebSock
 	}
 }
 
+// IssueTicket returns a single-use ticket for opening a WebSocket connection
+// with the current session
+func (h *WebSocketHandler) IssueTicket(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	username := c.Locals("username").(string)
+	sessionID := c.Locals("session_id").(int)
+
+	ticket, expiresAt, err := h.tickets.Issue(context.Background(), auth.Ticket{
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to issue ticket",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(model.WSTicketResponse{
+		Ticket:    ticket,
+		ExpiresAt: expiresAt,
+	})
+}
+
 // HandleWebSocket handles WebSocket connections
 func (h *WebSocketHandler) HandleWebSocket() fiber.Handler {
 	return func(c fiber.Ctx) error {
-		// Get token from query parameter
-		token := c.Query("token")
-		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "missing token",
+		// Negotiate the protocol first so an unusable ticket is not consumed
+		version, err := negotiateVersion(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
 			})
 		}
 
-		// Verify token
-		payload, err := h.authService.VerifyToken(token)
+		// Clients that reconnect pass the last event sequence they saw
+		var since int64 = -1
+		if value := c.Query("since"); value != "" {
+			since, err = strconv.ParseInt(value, 10, 64)
+			if err != nil || since < 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "invalid since",
+				})
+			}
+		}
+
+		identity, err := h.authenticate(c)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "invalid or expired token",
-			})
+			switch {
+			case errors.Is(err, errMissingCredentials),
+				errors.Is(err, errInvalidToken),
+				errors.Is(err, errSessionRevoked),
+				errors.Is(err, auth.ErrInvalidTicket):
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "failed to authenticate connection",
+				})
+			}
 		}
 
-		userID := payload.UserID
-		username := payload.Username
+		userID := identity.UserID
+		username := identity.Username
+		sessionID := identity.SessionID
 
 		// Upgrade to websocket
 		if err := h.upgrader.Upgrade(c.RequestCtx(), func(conn *websocket.Conn) {
-			defer func() {
-				h.clientsMu.Lock()
-				delete(h.clients, userID)
-				h.clientsMu.Unlock()
-
-				// Remove from all chat rooms
-				h.roomsMu.Lock()
-				for chatID, members := range h.chatRooms {
-					delete(members, userID)
-					if len(members) == 0 {
-						delete(h.chatRooms, chatID)
-					}
-				}
-				h.roomsMu.Unlock()
-
-				conn.Close()
-				log.Printf("User %s (ID: %d) disconnected", username, userID)
-			}()
-
+			client := h.hub.Register(conn, userID, username, sessionID, version)
 			log.Printf("User %s (ID: %d) connected via WebSocket", username, userID)
 
-			// Register client
-			h.clientsMu.Lock()
-			h.clients[userID] = conn
-			h.clientsMu.Unlock()
-
-			// Handle incoming messages
-			for {
-				var wsMsg model.WSMessage
-				err := conn.ReadJSON(&wsMsg)
-				if err != nil {
-					log.Printf("Read error for user %d: %v", userID, err)
-					break
-				}
-
-				switch wsMsg.Type {
-				case "message":
-					h.handleChatMessage(userID, username, wsMsg.Payload)
-				case "join_chat":
-					h.handleJoinChat(userID, wsMsg.Payload)
-				case "leave_chat":
-					h.handleLeaveChat(userID, wsMsg.Payload)
-				default:
-					log.Printf("Unknown message type: %s", wsMsg.Type)
-				}
+			if since >= 0 {
+				h.replay(client, since)
 			}
+
+			// Handle incoming frames until the connection goes away
+			err := client.ReadPump(func(data []byte) {
+				h.handleFrame(client, version, data)
+			})
+			log.Printf("User %s (ID: %d) disconnected: %v", username, userID, err)
 		}); err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "websocket upgrade failed",
@@ -124,203 +164,665 @@ func (h *WebSocketHandler) HandleWebSocket() fiber.Handler {
 	}
 }
 
-func (h *WebSocketHandler) handleChatMessage(userID int, username string, payload interface{}) {
-	// Parse payload
-	payloadBytes, err := json.Marshal(payload)
+// authenticate accepts, in order, a ticket from POST /ws/ticket in ?ticket=,
+// an access token offered as a "bearer.<token>" subprotocol, and, only when
+// enabled for migration, an access token in ?token=.
+func (h *WebSocketHandler) authenticate(c fiber.Ctx) (auth.Ticket, error) {
+	ctx := context.Background()
+
+	var identity auth.Ticket
+	if ticket := c.Query("ticket"); ticket != "" {
+		var err error
+		identity, err = h.tickets.Redeem(ctx, ticket)
+		if err != nil {
+			return auth.Ticket{}, err
+		}
+	} else {
+		token := bearerSubprotocol(c.Get(fiber.HeaderSecWebSocketProtocol))
+		if token == "" && h.allowQueryToken {
+			token = c.Query("token")
+		}
+		if token == "" {
+			return auth.Ticket{}, errMissingCredentials
+		}
+
+		payload, err := h.authService.VerifyToken(token)
+		if err != nil {
+			return auth.Ticket{}, errInvalidToken
+		}
+		identity = auth.Ticket{
+			UserID:    payload.UserID,
+			Username:  payload.Username,
+			SessionID: payload.SessionID,
+		}
+	}
+
+	// The session may have been revoked after the ticket or token was issued
+	active, err := h.sessionService.IsSessionActive(ctx, identity.SessionID)
 	if err != nil {
-		log.Printf("Error marshaling payload: %v", err)
+		return auth.Ticket{}, err
+	}
+	if !active {
+		return auth.Ticket{}, errSessionRevoked
+	}
+
+	return identity, nil
+}
+
+// bearerSubprotocol extracts the access token from a Sec-WebSocket-Protocol
+// header such as "chatapp, bearer.v4.local.xxx".
+func bearerSubprotocol(header string) string {
+	for _, protocol := range strings.Split(header, ",") {
+		protocol = strings.TrimSpace(protocol)
+		if strings.HasPrefix(protocol, wsBearerProtocol) {
+			return strings.TrimPrefix(protocol, wsBearerProtocol)
+		}
+	}
+	return ""
+}
+
+// handleFrame decodes a client frame and dispatches it by type. Every frame
+// is answered with an ack or an error frame.
+func (h *WebSocketHandler) handleFrame(client *hub.Client, version int, data []byte) {
+	var frame model.WSRequest
+	if err := decodeStrict(data, &frame); err != nil {
+		h.sendError(client, "", model.WSErrorInvalidFrame, "frame is not a valid envelope: "+err.Error())
+		return
+	}
+	if frame.V != version {
+		h.sendError(client, frame.ID, model.WSErrorUnsupportedVersion,
+			fmt.Sprintf("this connection uses protocol version %d", version))
+		return
+	}
+	if errs := f.ValidateStruct(frame); len(errs) > 0 {
+		h.sendError(client, frame.ID, model.WSErrorInvalidFrame, validationMessage(errs))
 		return
 	}
 
-	var msgReq struct {
-		ChatID  int    `json:"chat_id"`
-		Content string `json:"content"`
+	switch frame.Type {
+	case "message":
+		h.handleChatMessage(client, frame)
+	case "join_chat":
+		h.handleJoinChat(client, frame)
+	case "leave_chat":
+		h.handleLeaveChat(client, frame)
+	case hub.TypingStart:
+		h.handleTyping(client, frame, true)
+	case hub.TypingStop:
+		h.handleTyping(client, frame, false)
+	case "presence.set":
+		h.handlePresenceSet(client, frame)
+	case "read":
+		h.handleRead(client, frame)
+	default:
+		h.sendError(client, frame.ID, model.WSErrorUnknownType, fmt.Sprintf("unknown frame type %q", frame.Type))
 	}
-	err = json.Unmarshal(payloadBytes, &msgReq)
-	if err != nil {
-		log.Printf("Error unmarshaling message request: %v", err)
+}
+
+func (h *WebSocketHandler) handleChatMessage(client *hub.Client, frame model.WSRequest) {
+	var req model.WSSendMessagePayload
+	if !h.decodePayload(client, frame, &req) {
 		return
 	}
 
 	// Check if user is a member of the chat
-	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), msgReq.ChatID, userID)
-	if err != nil || !isMember {
-		log.Printf("User %d is not a member of chat %d", userID, msgReq.ChatID)
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), req.ChatID, client.UserID)
+	if err != nil {
+		log.Printf("Error checking membership of user %d in chat %d: %v", client.UserID, req.ChatID, err)
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to check chat membership")
+		return
+	}
+	if !isMember {
+		h.sendError(client, frame.ID, model.WSErrorNotMember, "you are not a member of this chat")
 		return
 	}
 
-	// Create message in database
-	msg, err := h.messageService.SendMessage(context.Background(), msgReq.ChatID, userID, msgReq.Content)
+	// Create message in database; members receive it as a message.created event
+	msg, err := h.messageService.SendMessage(context.Background(), req.ChatID, client.UserID, req.Content, req.ReplyToID)
 	if err != nil {
+		if errors.Is(err, service.ErrMessageNotInChat) {
+			h.sendError(client, frame.ID, model.WSErrorInvalidPayload, "reply_to_id must be a message of the same chat")
+			return
+		}
 		log.Printf("Error creating message: %v", err)
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to send message")
 		return
 	}
 
-	// Broadcast message to all members of the chat
-	wsChatMsg := model.WSChatMessage{
+	h.sendAck(client, frame.ID, model.WSAckPayload{
+		ChatID:    req.ChatID,
 		MessageID: msg.ID,
-		Content:   msg.Content,
-		SenderID:  userID,
-		Username:  username,
-		ChatID:    msgReq.ChatID,
-		Timestamp: msg.CreatedAt,
+	})
+}
+
+// handleRead moves the read cursor of the client's user up to a message.
+func (h *WebSocketHandler) handleRead(client *hub.Client, frame model.WSRequest) {
+	var req model.WSReadPayload
+	if !h.decodePayload(client, frame, &req) {
+		return
+	}
+
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), req.ChatID, client.UserID)
+	if err != nil {
+		log.Printf("Error checking membership of user %d in chat %d: %v", client.UserID, req.ChatID, err)
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to check chat membership")
+		return
+	}
+	if !isMember {
+		h.sendError(client, frame.ID, model.WSErrorNotMember, "you are not a member of this chat")
+		return
+	}
+
+	if _, err := h.chatService.MarkRead(context.Background(), req.ChatID, client.UserID, req.MessageID); err != nil {
+		if errors.Is(err, service.ErrMessageNotInChat) {
+			h.sendError(client, frame.ID, model.WSErrorInvalidPayload, "message does not belong to this chat")
+			return
+		}
+		log.Printf("Error marking chat %d as read for user %d: %v", req.ChatID, client.UserID, err)
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to mark chat as read")
+		return
 	}
 
-	h.broadcastToChat(msgReq.ChatID, model.WSMessage{
-		Type:    "message",
-		Payload: wsChatMsg,
+	h.sendAck(client, frame.ID, model.WSAckPayload{
+		ChatID:    req.ChatID,
+		MessageID: req.MessageID,
 	})
 }
 
-func (h *WebSocketHandler) handleJoinChat(userID int, payload interface{}) {
-	payloadBytes, err := json.Marshal(payload)
+func (h *WebSocketHandler) handleJoinChat(client *hub.Client, frame model.WSRequest) {
+	var req model.WSChatRoomPayload
+	if !h.decodePayload(client, frame, &req) {
+		return
+	}
+
+	// Verify user is a member of the chat
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), req.ChatID, client.UserID)
 	if err != nil {
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to check chat membership")
+		return
+	}
+	if !isMember {
+		h.sendError(client, frame.ID, model.WSErrorNotMember, "you are not a member of this chat")
+		return
+	}
+
+	// Add this connection to the chat room
+	h.hub.Join(client, req.ChatID)
+	h.sendAck(client, frame.ID, model.WSAckPayload{ChatID: req.ChatID})
+
+	log.Printf("User %d joined chat %d", client.UserID, req.ChatID)
+}
+
+func (h *WebSocketHandler) handleLeaveChat(client *hub.Client, frame model.WSRequest) {
+	var req model.WSChatRoomPayload
+	if !h.decodePayload(client, frame, &req) {
 		return
 	}
 
-	var req struct {
-		ChatID int `json:"chat_id"`
+	// Remove this connection from the chat room
+	h.hub.Leave(client, req.ChatID)
+	h.sendAck(client, frame.ID, model.WSAckPayload{ChatID: req.ChatID})
+
+	log.Printf("User %d left chat %d", client.UserID, req.ChatID)
+}
+
+// handleTyping passes a typing indicator on to the other members of the chat.
+// Starts repeated within the throttle interval are acknowledged but not sent
+// again.
+func (h *WebSocketHandler) handleTyping(client *hub.Client, frame model.WSRequest, active bool) {
+	var req model.WSChatRoomPayload
+	if !h.decodePayload(client, frame, &req) {
+		return
+	}
+
+	if active && client.TypingThrottled(req.ChatID) {
+		h.sendAck(client, frame.ID, model.WSAckPayload{ChatID: req.ChatID})
+		return
 	}
-	err = json.Unmarshal(payloadBytes, &req)
+
+	memberIDs, err := h.chatService.ListMemberIDs(context.Background(), req.ChatID)
 	if err != nil {
+		log.Printf("Error listing members of chat %d: %v", req.ChatID, err)
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to check chat membership")
 		return
 	}
 
-	// Verify user is a member of the chat
-	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), req.ChatID, userID)
-	if err != nil || !isMember {
+	isMember := false
+	recipients := make([]int, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		if id == client.UserID {
+			isMember = true
+		} else {
+			recipients = append(recipients, id)
+		}
+	}
+	if !isMember {
+		h.sendError(client, frame.ID, model.WSErrorNotMember, "you are not a member of this chat")
 		return
 	}
 
-	// Add user to chat room
-	h.roomsMu.Lock()
-	if h.chatRooms[req.ChatID] == nil {
-		h.chatRooms[req.ChatID] = make(map[int]bool)
+	if err := h.hub.SetTyping(context.Background(), client, req.ChatID, active, recipients); err != nil {
+		log.Printf("Error publishing typing indicator: %v", err)
+		h.sendError(client, frame.ID, model.WSErrorInternal, "failed to send typing indicator")
+		return
+	}
+	h.sendAck(client, frame.ID, model.WSAckPayload{ChatID: req.ChatID})
+}
+
+// ListTyping returns who is currently typing in a chat, for clients that poll
+func (h *WebSocketHandler) ListTyping(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	chatID, err := utils.ParamsInt(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "invalid chat id",
+		})
+	}
+
+	// Check if user is a member
+	isMember, err := h.chatService.IsUserMemberOfChat(context.Background(), chatID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to check membership",
+		})
+	}
+	if !isMember {
+		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse{
+			Error: "you are not a member of this chat",
+		})
 	}
-	h.chatRooms[req.ChatID][userID] = true
-	h.roomsMu.Unlock()
 
-	log.Printf("User %d joined chat %d", userID, req.ChatID)
+	typing := h.hub.Typing(chatID)
+	responses := make([]model.TypingUserResponse, 0, len(typing))
+	for _, t := range typing {
+		if t.UserID == userID {
+			continue
+		}
+		responses = append(responses, model.TypingUserResponse{
+			UserID:   t.UserID,
+			Username: t.Username,
+		})
+	}
+
+	return c.JSON(responses)
+}
+
+// decodePayload strictly decodes and validates the payload of a frame into
+// dst. On failure it answers with an error frame and returns false.
+func (h *WebSocketHandler) decodePayload(client *hub.Client, frame model.WSRequest, dst interface{}) bool {
+	if len(frame.Payload) == 0 {
+		h.sendError(client, frame.ID, model.WSErrorInvalidPayload, "missing payload")
+		return false
+	}
+	if err := decodeStrict(frame.Payload, dst); err != nil {
+		h.sendError(client, frame.ID, model.WSErrorInvalidPayload, err.Error())
+		return false
+	}
+	if errs := f.ValidateStruct(dst); len(errs) > 0 {
+		h.sendError(client, frame.ID, model.WSErrorInvalidPayload, validationMessage(errs))
+		return false
+	}
+	return true
+}
+
+func (h *WebSocketHandler) sendAck(client *hub.Client, id string, payload model.WSAckPayload) {
+	h.sendToClient(client, model.WSMessage{
+		V:       client.Version,
+		Type:    "ack",
+		ID:      id,
+		Payload: payload,
+	})
+}
+
+func (h *WebSocketHandler) sendError(client *hub.Client, id, code, message string) {
+	h.sendToClient(client, model.WSMessage{
+		V:    client.Version,
+		Type: "error",
+		ID:   id,
+		Payload: model.WSErrorPayload{
+			Code:    code,
+			Message: message,
+		},
+	})
 }
 
-func (h *WebSocketHandler) handleLeaveChat(userID int, payload interface{}) {
-	payloadBytes, err := json.Marshal(payload)
+func (h *WebSocketHandler) sendToClient(client *hub.Client, message model.WSMessage) {
+	data, err := json.Marshal(message)
 	if err != nil {
+		log.Printf("Error encoding %s frame: %v", message.Type, err)
 		return
 	}
+	client.Send(data)
+}
+
+// newWSMessage builds a server event frame
+func newWSMessage(messageType string, payload interface{}) model.WSMessage {
+	return model.WSMessage{
+		V:       model.WSProtocolVersion,
+		Type:    messageType,
+		Payload: payload,
+	}
+}
+
+// decodeStrict decodes a single JSON value and rejects unknown fields.
+func decodeStrict(data []byte, dst interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
 
-	var req struct {
-		ChatID int `json:"chat_id"`
+func validationMessage(errs []*f.ErrorResponse) string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = fmt.Sprintf("%s: %s", e.Field, e.Value)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// negotiateVersion picks the protocol version of a connection from the
+// "chatapp.v<N>" subprotocols or ?v=. Without either the newest version is
+// used. The plain "chatapp" subprotocol also means the newest version.
+func negotiateVersion(c fiber.Ctx) (int, error) {
+	var offered []string
+	for _, protocol := range strings.Split(c.Get(fiber.HeaderSecWebSocketProtocol), ",") {
+		protocol = strings.TrimSpace(protocol)
+		if strings.HasPrefix(protocol, wsSubprotocol+".v") {
+			offered = append(offered, strings.TrimPrefix(protocol, wsSubprotocol+".v"))
+		}
 	}
-	err = json.Unmarshal(payloadBytes, &req)
+	if v := c.Query("v"); v != "" {
+		offered = append(offered, v)
+	}
+	if len(offered) == 0 {
+		return model.WSProtocolVersion, nil
+	}
+
+	for _, v := range offered {
+		if version, err := strconv.Atoi(v); err == nil && isSupportedVersion(version) {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported protocol version, the server speaks version %d", model.WSProtocolVersion)
+}
+
+func isSupportedVersion(version int) bool {
+	return version >= model.WSMinProtocolVersion && version <= model.WSProtocolVersion
+}
+
+// wsSubprotocols lists the subprotocols of every supported version, newest
+// first, then plain "chatapp".
+func wsSubprotocols() []string {
+	var protocols []string
+	for version := model.WSProtocolVersion; version >= model.WSMinProtocolVersion; version-- {
+		protocols = append(protocols, fmt.Sprintf("%s.v%d", wsSubprotocol, version))
+	}
+	return append(protocols, wsSubprotocol)
+}
+
+func (h *WebSocketHandler) broadcastToChat(chatID int, message model.WSMessage) {
+	// Every member gets the event on every device, joined to the room or not,
+	// so their event sequences have no gaps
+	chatEntity, err := h.chatService.GetChatByID(context.Background(), chatID)
 	if err != nil {
+		log.Printf("Error getting chat members: %v", err)
 		return
 	}
 
-	// Remove user from chat room
-	h.roomsMu.Lock()
-	if h.chatRooms[req.ChatID] != nil {
-		delete(h.chatRooms[req.ChatID], userID)
-		if len(h.chatRooms[req.ChatID]) == 0 {
-			delete(h.chatRooms, req.ChatID)
+	var userIDs []int
+	for _, membership := range chatEntity.Edges.Members {
+		if membership.Edges.User != nil {
+			userIDs = append(userIDs, membership.Edges.User.ID)
 		}
 	}
-	h.roomsMu.Unlock()
+	h.publishEvent(userIDs, message.Type, message.Payload)
+}
 
-	log.Printf("User %d left chat %d", userID, req.ChatID)
+// publishEvent logs an event for each user and delivers it with the user's
+// sequence number, so clients that miss it can replay it later.
+func (h *WebSocketHandler) publishEvent(userIDs []int, eventType string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Error encoding %s event: %v", eventType, err)
+		return
+	}
+
+	err = h.eventLog.Record(context.Background(), userIDs, eventType, json.RawMessage(data), func(events map[int]*ent.UserEvent) {
+		seqs := make(map[int]int64, len(events))
+		for userID, event := range events {
+			seqs[userID] = event.Seq
+		}
+		if err := h.hub.SendEvent(context.Background(), eventType, data, seqs); err != nil {
+			log.Printf("Error publishing %s event: %v", eventType, err)
+		}
+	})
+	if err != nil {
+		log.Printf("Error logging %s event: %v", eventType, err)
+		// Nothing was delivered, so still deliver live without a sequence
+		// number; only replay is lost
+		h.sendToUsers(userIDs, newWSMessage(eventType, payload))
+	}
 }
 
-func (h *WebSocketHandler) broadcastToChat(chatID int, message model.WSMessage) {
-	h.roomsMu.RLock()
-	members := h.chatRooms[chatID]
-	h.roomsMu.RUnlock()
+// replay sends the events the client missed since the given sequence, or a
+// resync_required frame if they are no longer available.
+func (h *WebSocketHandler) replay(client *hub.Client, since int64) {
+	replay, err := h.eventLog.Since(context.Background(), client.UserID, since)
+	if err != nil {
+		log.Printf("Error loading missed events of user %d: %v", client.UserID, err)
+		h.sendError(client, "", model.WSErrorInternal, "failed to replay missed events")
+		return
+	}
 
-	if members == nil {
-		// If no one is in the room, get all members from database
-		chatEntity, err := h.chatService.GetChatByID(context.Background(), chatID)
+	var frames []hub.Frame
+	if replay.ResyncRequired {
+		payload, err := json.Marshal(model.WSResyncPayload{
+			LatestSeq: replay.LatestSeq,
+		})
 		if err != nil {
-			log.Printf("Error getting chat members: %v", err)
 			return
 		}
-
-		for _, membership := range chatEntity.Edges.Members {
-			if membership.Edges.User != nil {
-				h.sendToUser(membership.Edges.User.ID, message)
-			}
+		data, err := hub.EncodeFrame(client.Version, "resync_required", payload, 0)
+		if err != nil {
+			return
 		}
-		return
+		// The client reloads everything up to LatestSeq, so skip those live
+		frames = append(frames, hub.Frame{Data: data, Seq: replay.LatestSeq})
+	}
+	for _, event := range replay.Events {
+		data, err := hub.EncodeFrame(client.Version, event.Type, event.Payload, event.Seq)
+		if err != nil {
+			return
+		}
+		frames = append(frames, hub.Frame{
+			Data:    data,
+			Seq:     event.Seq,
+			Type:    event.Type,
+			Payload: event.Payload,
+		})
 	}
 
-	// Send to connected members
-	for memberID := range members {
-		h.sendToUser(memberID, message)
+	if err := client.Replay(frames); err != nil {
+		client.Close(websocket.CloseInternalServerErr, "replay failed")
 	}
 }
 
-func (h *WebSocketHandler) sendToUser(userID int, message model.WSMessage) {
-	h.clientsMu.RLock()
-	conn, exists := h.clients[userID]
-	h.clientsMu.RUnlock()
-
-	if !exists {
-		return
+func (h *WebSocketHandler) sendToUsers(userIDs []int, message model.WSMessage) {
+	if err := h.hub.SendToUsers(context.Background(), userIDs, message.Type, message.Payload); err != nil {
+		log.Printf("Error sending message to users %v: %v", userIDs, err)
 	}
+}
 
-	err := conn.WriteJSON(message)
-	if err != nil {
-		log.Printf("Error sending message to user %d: %v", userID, err)
-		// Connection might be dead, remove it
-		h.clientsMu.Lock()
-		delete(h.clients, userID)
-		h.clientsMu.Unlock()
+// CloseSessions forcibly disconnects every connection that was authenticated
+// with one of the given sessions. It is registered as a session revoke hook.
+func (h *WebSocketHandler) CloseSessions(sessionIDs []int) {
+	if err := h.hub.CloseSessions(context.Background(), sessionIDs, websocket.ClosePolicyViolation, "session revoked"); err != nil {
+		log.Printf("Error closing connections of revoked sessions: %v", err)
 	}
 }
 
 // Helper function to broadcast a system message
 func (h *WebSocketHandler) BroadcastSystemMessage(chatID int, message string) error {
-	h.broadcastToChat(chatID, model.WSMessage{
-		Type: "system",
-		Payload: map[string]interface{}{
-			"chat_id": chatID,
-			"message": message,
-		},
-	})
+	h.broadcastToChat(chatID, newWSMessage("system", model.WSSystemPayload{
+		ChatID:  chatID,
+		Message: message,
+	}))
 	return nil
 }
 
-// Helper to notify users about new chat
-func (h *WebSocketHandler) NotifyNewChat(userIDs []int, chat model.ChatResponse) {
-	message := model.WSMessage{
-		Type:    "new_chat",
-		Payload: chat,
+// HandleEvent delivers a domain event to its recipients. It is subscribed to
+// the event bus, so changes made over the REST API reach connected clients too.
+func (h *WebSocketHandler) HandleEvent(_ context.Context, e event.Event) {
+	var payload interface{}
+	switch e.Type {
+	case event.MessageCreated, event.MessageUpdated:
+		if e.Message == nil {
+			return
+		}
+		payload = wsChatMessage(e.ChatID, e.Message)
+	case event.MessageDeleted:
+		if e.Message == nil {
+			return
+		}
+		payload = model.WSMessageDeletedPayload{
+			MessageID: e.Message.ID,
+			ChatID:    e.ChatID,
+		}
+	case event.MessageDelivered:
+		deliveries := make([]model.WSDelivery, 0, len(e.Deliveries))
+		for _, d := range e.Deliveries {
+			deliveries = append(deliveries, model.WSDelivery{
+				MessageID:   d.MessageID,
+				ChatID:      d.ChatID,
+				UserID:      d.UserID,
+				DeliveredAt: d.DeliveredAt,
+			})
+		}
+		payload = model.WSMessageDeliveredPayload{Deliveries: deliveries}
+	case event.ThreadUpdated:
+		if e.Message == nil {
+			return
+		}
+		replyCount, lastReplyAt := service.MessageReplies(e.Message)
+		payload = model.WSThreadPayload{
+			ChatID:      e.ChatID,
+			MessageID:   e.Message.ID,
+			ReplyCount:  replyCount,
+			LastReplyAt: lastReplyAt,
+		}
+	case event.ReactionAdded, event.ReactionRemoved:
+		if e.Message == nil {
+			return
+		}
+		payload = model.WSReactionPayload{
+			ChatID:    e.ChatID,
+			MessageID: e.Message.ID,
+			UserID:    e.UserID,
+			Emoji:     e.Emoji,
+		}
+	case event.ChatCreated, event.ChatUpdated:
+		if e.Chat == nil {
+			return
+		}
+		creatorID := 0
+		if e.Chat.Edges.Creator != nil {
+			creatorID = e.Chat.Edges.Creator.ID
+		}
+		payload = model.ChatResponse{
+			ID:        e.Chat.ID,
+			Name:      e.Chat.Name,
+			IsGroup:   e.Chat.IsGroup,
+			CreatorID: creatorID,
+			CreatedAt: e.Chat.CreatedAt,
+			UpdatedAt: e.Chat.UpdatedAt,
+		}
+	case event.ChatDeleted:
+		payload = model.WSChatRoomPayload{ChatID: e.ChatID}
+	case event.MemberAdded, event.MemberRemoved:
+		payload = model.WSMemberPayload{
+			ChatID:  e.ChatID,
+			UserIDs: e.MemberIDs,
+		}
+	case event.Read:
+		m := e.Member
+		if m == nil || m.Edges.User == nil || m.LastReadMessageID == nil || m.LastReadAt == nil {
+			return
+		}
+		payload = model.WSReadReceiptPayload{
+			ChatID:    e.ChatID,
+			UserID:    m.Edges.User.ID,
+			MessageID: *m.LastReadMessageID,
+			ReadAt:    *m.LastReadAt,
+		}
+	default:
+		return
 	}
 
-	for _, userID := range userIDs {
-		h.sendToUser(userID, message)
-	}
+	h.publishEvent(e.Recipients, e.Type, payload)
 }
 
-// Health check for websocket service
-func (h *WebSocketHandler) GetStats() map[string]interface{} {
-	h.clientsMu.RLock()
-	connectedUsers := len(h.clients)
-	h.clientsMu.RUnlock()
+// HandleDelivered records that a new message reached a connection of one of
+// its recipients. It is registered as the hub's delivery hook.
+func (h *WebSocketHandler) HandleDelivered(userID int, eventType string, payload json.RawMessage) {
+	if eventType != event.MessageCreated {
+		return
+	}
+	var msg model.WSChatMessage
+	if err := json.Unmarshal(payload, &msg); err != nil || msg.SenderID == userID {
+		return
+	}
 
-	h.roomsMu.RLock()
-	activeRooms := len(h.chatRooms)
-	h.roomsMu.RUnlock()
+	// The hook runs in the connection's write loop, so the delivery is
+	// recorded later with others
+	h.deliveries.Add(service.Receipt{MessageID: msg.MessageID, UserID: userID})
+}
 
-	return map[string]interface{}{
-		"connected_users": connectedUsers,
-		"active_rooms":    activeRooms,
-		"status":          "healthy",
+func wsChatMessage(chatID int, msg *ent.Message) model.WSChatMessage {
+	payload := model.WSChatMessage{
+		MessageID: msg.ID,
+		Content:   msg.Content,
+		ChatID:    chatID,
+		Timestamp: msg.CreatedAt,
+		UpdatedAt: msg.UpdatedAt,
+	}
+	if msg.Edges.Sender != nil {
+		payload.SenderID = msg.Edges.Sender.ID
+		payload.Username = msg.Edges.Sender.Username
 	}
+	if msg.Edges.ReplyTo != nil {
+		payload.ReplyToID = msg.Edges.ReplyTo.ID
+	}
+	return payload
+}
+
+// Health check for websocket service
+func (h *WebSocketHandler) GetStats() hub.Stats {
+	return h.hub.Stats()
 }
 
 func (h *WebSocketHandler) HealthCheck(c fiber.Ctx) error {
 	stats := h.GetStats()
-	return c.JSON(fiber.Map{
-		"websocket": stats,
-		"message":   fmt.Sprintf("%d users connected, %d rooms active", stats["connected_users"], stats["active_rooms"]),
+	status, code := "healthy", fiber.StatusOK
+	if !stats.BrokerConnected {
+		status, code = "unhealthy", fiber.StatusServiceUnavailable
+	}
+	return c.Status(code).JSON(fiber.Map{
+		"websocket": fiber.Map{
+			"broker_connected":            stats.BrokerConnected,
+			"connected_users":             stats.ConnectedUsers,
+			"connections":                 stats.Connections,
+			"active_rooms":                stats.ActiveRooms,
+			"slow_consumers_disconnected": stats.SlowConsumers,
+			"reaped_heartbeat":            stats.ReapedHeartbeat,
+			"reaped_idle":                 stats.ReapedIdle,
+			"reaped_oversized":            stats.ReapedOversized,
+			"status":                      status,
+		},
+		"message": fmt.Sprintf("%d users connected on %d connections, %d rooms active", stats.ConnectedUsers, stats.Connections, stats.ActiveRooms),
 	})
 }
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/hub"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/gofiber/fiber/v3"
+)
+
+// maxPresenceLookup is the most users GetPresence returns at once.
+const maxPresenceLookup = 100
+
+// handlePresenceSet marks the connection as away, e.g. while its tab is
+// hidden, or active again.
+func (h *WebSocketHandler) handlePresenceSet(client *hub.Client, frame model.WSRequest) {
+	var req model.WSPresenceSetPayload
+	if !h.decodePayload(client, frame, &req) {
+		return
+	}
+
+	h.hub.SetAway(client, req.Status == hub.StatusAway)
+	h.sendAck(client, frame.ID, model.WSAckPayload{})
+}
+
+// HandlePresenceChange records when a user went offline and tells the users
+// who share a chat with them. It is registered as the hub's presence hook.
+func (h *WebSocketHandler) HandlePresenceChange(userID int, status string, at time.Time) {
+	ctx := context.Background()
+
+	if status == hub.StatusOffline {
+		if err := h.userService.UpdateLastSeen(ctx, userID, at); err != nil {
+			log.Printf("Error updating last seen of user %d: %v", userID, err)
+		}
+	}
+
+	u, err := h.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		// Deleted accounts are not announced
+		return
+	}
+
+	contactIDs, err := h.chatService.ListContactIDs(ctx, userID)
+	if err != nil {
+		log.Printf("Error listing contacts of user %d: %v", userID, err)
+		return
+	}
+	if len(contactIDs) == 0 {
+		return
+	}
+
+	payload := model.PresenceResponse{
+		UserID: userID,
+		Status: status,
+	}
+	if status == hub.StatusOffline && !u.HideLastSeen {
+		payload.LastSeen = &at
+	}
+	h.sendToUsers(contactIDs, newWSMessage("presence.changed", payload))
+}
+
+// GetPresence returns the status of up to 100 users given as ?ids=1,2,3.
+// Users who share no chat with the caller are left out, like they are from
+// presence.changed events.
+func (h *WebSocketHandler) GetPresence(c fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	var ids []int
+	for _, value := range strings.Split(c.Query("ids"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "invalid user id",
+			})
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 || len(ids) > maxPresenceLookup {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+			Error: "between 1 and 100 user ids are required",
+		})
+	}
+
+	visibleIDs, err := h.chatService.FilterContactIDs(context.Background(), userID, ids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to get users",
+		})
+	}
+	if slices.Contains(ids, userID) {
+		visibleIDs = append(visibleIDs, userID)
+	}
+
+	users, err := h.userService.GetUsersByIDs(context.Background(), visibleIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse{
+			Error: "failed to get users",
+		})
+	}
+
+	responses := make([]model.PresenceResponse, 0, len(users))
+	for _, u := range users {
+		response := model.PresenceResponse{
+			UserID: u.ID,
+			Status: h.hub.Status(u.ID),
+		}
+		if response.Status == hub.StatusOffline {
+			response.LastSeen = publicLastSeen(u)
+		}
+		responses = append(responses, response)
+	}
+
+	return c.JSON(responses)
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/hub"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/gofiber/fiber/v3"
+)
+
+// wsFrameSpec documents one frame type of the WebSocket protocol. Payload is
+// a zero value of the payload struct, or nil for frames without payload.
+type wsFrameSpec struct {
+	Type        string
+	Payload     interface{}
+	Description string
+}
+
+// wsClientFrames are the frames clients may send. Every one of them is
+// answered with an ack or an error frame carrying the same id.
+var wsClientFrames = []wsFrameSpec{
+	{"message", model.WSSendMessagePayload{}, "Send a message to a chat, optionally replying to a message of the chat. The ack carries the persisted message_id."},
+	{"join_chat", model.WSChatRoomPayload{}, "Mark a chat as open on this connection."},
+	{"leave_chat", model.WSChatRoomPayload{}, "Mark a chat as no longer open on this connection."},
+	{"typing.start", model.WSChatRoomPayload{}, "The user is typing in a chat. Repeat it while typing; it expires otherwise."},
+	{"typing.stop", model.WSChatRoomPayload{}, "The user stopped typing in a chat."},
+	{"presence.set", model.WSPresenceSetPayload{}, "Mark this connection as away, e.g. while the tab is hidden, or online again."},
+	{"read", model.WSReadPayload{}, "Mark a chat as read up to a message. The read cursor never moves back."},
+}
+
+// wsServerFrames are the frames the server sends.
+var wsServerFrames = []wsFrameSpec{
+	{"ack", model.WSAckPayload{}, "A request was processed."},
+	{"error", model.WSErrorPayload{}, "A request was rejected. id is empty if the frame could not be parsed."},
+	{"message.created", model.WSChatMessage{}, "A new message in a chat."},
+	{"message.updated", model.WSChatMessage{}, "A message was edited."},
+	{"message.deleted", model.WSMessageDeletedPayload{}, "A message was deleted."},
+	{"thread.updated", model.WSThreadPayload{}, "A reply to a message was sent or deleted."},
+	{"reaction.added", model.WSReactionPayload{}, "A member reacted to a message."},
+	{"reaction.removed", model.WSReactionPayload{}, "A member took back a reaction."},
+	{"message.delivered", model.WSMessageDeliveredPayload{}, "A message of the user reached one of the other members. Sent to the sender only."},
+	{"chat.created", model.ChatResponse{}, "The user was added to a new chat."},
+	{"chat.updated", model.ChatResponse{}, "A chat was renamed."},
+	{"chat.deleted", model.WSChatRoomPayload{}, "A chat was deleted."},
+	{"member.added", model.WSMemberPayload{}, "Users were added to a chat. New members receive it too."},
+	{"member.removed", model.WSMemberPayload{}, "Users were removed from a chat. Removed members receive it too."},
+	{"typing.start", model.WSTypingPayload{}, "Another member is typing in a chat."},
+	{"typing.stop", model.WSTypingPayload{}, "Another member stopped typing, or their indicator expired."},
+	{"read", model.WSReadReceiptPayload{}, "A member read a chat up to a message. Also sent to the reader's other connections."},
+	{"presence.changed", model.PresenceResponse{}, "A user who shares a chat came online, went away or went offline."},
+	{"system", model.WSSystemPayload{}, "A system notice in a chat."},
+	{"resync_required", model.WSResyncPayload{}, "Missed events can no longer be replayed; reload and continue from latest_seq."},
+}
+
+// Schema serves the JSON Schema of the WebSocket protocol, of the newest
+// version or the one given as ?v=
+func (h *WebSocketHandler) Schema(c fiber.Ctx) error {
+	version := model.WSProtocolVersion
+	if v := c.Query("v"); v != "" {
+		var err error
+		if version, err = strconv.Atoi(v); err != nil || !isSupportedVersion(version) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse{
+				Error: "unsupported protocol version",
+			})
+		}
+	}
+	return c.JSON(wsProtocolSchema(version))
+}
+
+// wsProtocolSchema describes every frame of a protocol version as a JSON
+// Schema (draft 2020-12). Client frames are under $defs/client, server frames
+// under $defs/server.
+func wsProtocolSchema(version int) fiber.Map {
+	return fiber.Map{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         fmt.Sprintf("chatapp:ws:v%d", version),
+		"title":       fmt.Sprintf("ChatApp WebSocket protocol v%d", version),
+		"description": fmt.Sprintf("Negotiate the version with the \"chatapp.v%d\" subprotocol or ?v=%d.", version, version),
+		"$defs": fiber.Map{
+			"client": wsFramesSchema(version, wsClientFrames, true),
+			"server": wsFramesSchema(version, wsServerFrames, false),
+		},
+		"oneOf": []fiber.Map{
+			{"$ref": "#/$defs/client"},
+			{"$ref": "#/$defs/server"},
+		},
+	}
+}
+
+func wsFramesSchema(version int, frames []wsFrameSpec, fromClient bool) fiber.Map {
+	variants := make([]fiber.Map, 0, len(frames))
+	for _, frame := range frames {
+		properties := fiber.Map{
+			"v":    fiber.Map{"const": version},
+			"type": fiber.Map{"const": frameType(version, frame.Type, fromClient)},
+			"id": fiber.Map{
+				"type":      "string",
+				"maxLength": 64,
+			},
+		}
+		required := []string{"v", "type"}
+		if frame.Payload != nil {
+			properties["payload"] = jsonSchemaOf(reflect.TypeOf(frame.Payload))
+			required = append(required, "payload")
+		}
+		if fromClient {
+			properties["id"].(fiber.Map)["description"] = "Request ID echoed in the ack or error frame"
+		} else {
+			properties["seq"] = fiber.Map{
+				"type":        "integer",
+				"minimum":     1,
+				"description": "Number of the event in the user's event sequence; reconnect with ?since=<seq>",
+			}
+		}
+
+		variants = append(variants, fiber.Map{
+			"description":          frame.Description,
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		})
+	}
+	return fiber.Map{"oneOf": variants}
+}
+
+// frameType names a frame type in a protocol version. Only server frames
+// were renamed between versions.
+func frameType(version int, name string, fromClient bool) string {
+	if fromClient {
+		return name
+	}
+	return hub.FrameType(version, name)
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// jsonSchemaOf derives a JSON Schema from a Go type using its json tags.
+// Fields without omitempty are required.
+func jsonSchemaOf(t reflect.Type) fiber.Map {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return fiber.Map{"type": "string", "format": "date-time"}
+	case t == rawJSONType:
+		return fiber.Map{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return fiber.Map{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fiber.Map{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return fiber.Map{"type": "number"}
+	case reflect.String:
+		return fiber.Map{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return fiber.Map{"type": "array", "items": jsonSchemaOf(t.Elem())}
+	case reflect.Map:
+		return fiber.Map{"type": "object", "additionalProperties": jsonSchemaOf(t.Elem())}
+	case reflect.Struct:
+		properties := fiber.Map{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = jsonSchemaOf(field.Type)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		return fiber.Map{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	default:
+		return fiber.Map{}
+	}
+}
@@ -0,0 +1,65 @@
+package hub
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker carries encoded hub messages between the nodes of a cluster. Every
+// published message reaches every subscribed node, including the publisher,
+// and each node delivers it to its own connections only.
+type Broker interface {
+	// Publish sends data to every node.
+	Publish(ctx context.Context, data []byte) error
+	// Subscribe calls handle with every published message until ctx is
+	// cancelled. Messages from one publisher arrive in order. It calls
+	// connected with true once messages are received, and with false while a
+	// broker that reconnects on its own is disconnected.
+	Subscribe(ctx context.Context, handle func(data []byte), connected func(bool)) error
+	// Close releases the connections of the broker.
+	Close() error
+}
+
+// MemoryBroker delivers messages within the process. It is suitable for a
+// single instance only.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	handlers map[int]func(data []byte)
+	nextID   int
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		handlers: make(map[int]func(data []byte)),
+	}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, data []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handle := range b.handlers {
+		handle(data)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, handle func(data []byte), connected func(bool)) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handle
+	b.mu.Unlock()
+	connected(true)
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
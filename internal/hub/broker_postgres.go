@@ -0,0 +1,206 @@
+package hub
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+)
+
+const (
+	postgresChannel = "chatapp_ws"
+	// NOTIFY payloads are limited to 8000 bytes, so larger messages are sent
+	// in chunks of at most this size plus a short header.
+	postgresChunkSize = 7000
+	// Chunks of a message that do not all arrive within this time are dropped.
+	postgresChunkTTL = time.Minute
+)
+
+// PostgresBroker exchanges messages with LISTEN/NOTIFY on the application
+// database, so several instances need nothing but Postgres.
+type PostgresBroker struct {
+	db       *sql.DB
+	listener *pq.Listener
+
+	mu      sync.Mutex
+	partial map[string]*partialMessage
+
+	// stateMu guards whether the listener is connected and who is told
+	// about changes
+	stateMu   sync.Mutex
+	listening bool
+	onState   func(bool)
+}
+
+type partialMessage struct {
+	chunks   []string
+	received int
+	started  time.Time
+}
+
+// NewPostgresBroker publishes through db and listens on a dedicated
+// connection opened with dsn, which reconnects on its own.
+func NewPostgresBroker(db *sql.DB, dsn string) (*PostgresBroker, error) {
+	b := &PostgresBroker{
+		db:      db,
+		partial: make(map[string]*partialMessage),
+	}
+	b.listener = pq.NewListener(dsn, time.Second, time.Minute, b.listenerEvent)
+	if err := b.listener.Listen(postgresChannel); err != nil {
+		_ = b.listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", postgresChannel, err)
+	}
+
+	return b, nil
+}
+
+// listenerEvent tracks whether the listener connection is up.
+func (b *PostgresBroker) listenerEvent(event pq.ListenerEventType, err error) {
+	if err != nil {
+		log.Printf("Postgres websocket broker: %v", err)
+	}
+
+	var listening bool
+	switch event {
+	case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+		listening = true
+	case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+		listening = false
+	default:
+		return
+	}
+
+	b.stateMu.Lock()
+	b.listening = listening
+	onState := b.onState
+	b.stateMu.Unlock()
+
+	if onState != nil {
+		onState(listening)
+	}
+}
+
+func (b *PostgresBroker) Publish(ctx context.Context, data []byte) error {
+	chunks := splitChunks(string(data), postgresChunkSize)
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Errorf("failed to generate message id: %w", err)
+	}
+	messageID := hex.EncodeToString(id)
+
+	for i, chunk := range chunks {
+		payload := fmt.Sprintf("%s:%d:%d:%s", messageID, i, len(chunks), chunk)
+		if _, err := b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", postgresChannel, payload); err != nil {
+			return fmt.Errorf("failed to notify: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *PostgresBroker) Subscribe(ctx context.Context, handle func(data []byte), connected func(bool)) error {
+	b.stateMu.Lock()
+	b.onState = connected
+	listening := b.listening
+	b.stateMu.Unlock()
+	connected(listening)
+
+	defer func() {
+		b.stateMu.Lock()
+		b.onState = nil
+		b.stateMu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n, ok := <-b.listener.Notify:
+			if !ok {
+				return nil
+			}
+			// nil is sent after a reconnect; notifications in between are lost
+			if n == nil {
+				continue
+			}
+			if data, ok := b.assemble(n.Extra); ok {
+				handle(data)
+			}
+		}
+	}
+}
+
+func (b *PostgresBroker) Close() error {
+	return b.listener.Close()
+}
+
+// assemble collects the chunks of a message and returns it once complete.
+func (b *PostgresBroker) assemble(payload string) ([]byte, bool) {
+	parts := strings.SplitN(payload, ":", 4)
+	if len(parts) != 4 {
+		return nil, false
+	}
+	index, err1 := strconv.Atoi(parts[1])
+	total, err2 := strconv.Atoi(parts[2])
+	if err1 != nil || err2 != nil || total < 1 || index < 0 || index >= total {
+		return nil, false
+	}
+	if total == 1 {
+		return []byte(parts[3]), true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for id, p := range b.partial {
+		if now.Sub(p.started) > postgresChunkTTL {
+			delete(b.partial, id)
+		}
+	}
+
+	p := b.partial[parts[0]]
+	if p == nil {
+		p = &partialMessage{chunks: make([]string, total), started: now}
+		b.partial[parts[0]] = p
+	}
+	if len(p.chunks) != total || p.chunks[index] != "" {
+		return nil, false
+	}
+	p.chunks[index] = parts[3]
+	p.received++
+	if p.received < total {
+		return nil, false
+	}
+
+	delete(b.partial, parts[0])
+	return []byte(strings.Join(p.chunks, "")), true
+}
+
+// splitChunks splits s into chunks of at most size bytes without splitting a
+// UTF-8 sequence, since NOTIFY payloads must be valid text.
+func splitChunks(s string, size int) []string {
+	if len(s) <= size {
+		return []string{s}
+	}
+
+	var chunks []string
+	for len(s) > size {
+		end := size
+		for end > 0 && !utf8.RuneStart(s[end]) {
+			end--
+		}
+		chunks = append(chunks, s[:end])
+		s = s[end:]
+	}
+	return append(chunks, s)
+}
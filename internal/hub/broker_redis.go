@@ -0,0 +1,56 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisChannel = "chatapp:ws"
+
+// RedisBroker exchanges messages over Redis pub/sub.
+type RedisBroker struct {
+	client *redis.Client
+}
+
+func NewRedisBroker(client *redis.Client) *RedisBroker {
+	return &RedisBroker{client: client}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, data []byte) error {
+	if err := b.client.Publish(ctx, redisChannel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish to redis: %w", err)
+	}
+	return nil
+}
+
+func (b *RedisBroker) Subscribe(ctx context.Context, handle func(data []byte), connected func(bool)) error {
+	sub := b.client.Subscribe(ctx, redisChannel)
+	defer sub.Close()
+
+	// Wait for the subscription to be confirmed so no message is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to redis: %w", err)
+	}
+	connected(true)
+
+	// The channel reconnects on its own after network errors
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handle([]byte(msg.Payload))
+		}
+	}
+}
+
+// Close does nothing; the Redis client is shared and closed by its owner.
+func (b *RedisBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,214 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fasthttp/websocket"
+)
+
+// closeGracePeriod bounds how long writing a close frame may take.
+const closeGracePeriod = time.Second
+
+// Client is one WebSocket connection. A user has one client per open tab or
+// device. Only the client's write pump writes data frames to the connection;
+// everyone else queues messages with Send.
+type Client struct {
+	hub       *Hub
+	conn      *websocket.Conn
+	UserID    int
+	Username  string
+	SessionID int
+	// Version is the protocol version the connection negotiated
+	Version int
+
+	send      chan Frame
+	done      chan struct{}
+	closeOnce sync.Once
+	// lastMessage is when the client last sent a data frame, in Unix
+	// nanoseconds. Pongs keep the connection alive but do not count.
+	lastMessage atomic.Int64
+	// away is set while the client reports the user as away, e.g. because
+	// its tab is hidden
+	away atomic.Bool
+	// replayedSeq is the last event sequence written by Replay. Live frames
+	// up to it are duplicates and skipped.
+	replayedSeq int64
+	// typingAnnounced is when typing was last announced per chat. It is only
+	// used by the read loop.
+	typingAnnounced map[int]time.Time
+}
+
+// Frame is an encoded message. Seq is its number in the user's event
+// sequence, or 0 for frames that are not logged.
+type Frame struct {
+	Data []byte
+	Seq  int64
+	// Type and Payload are what Data encodes, set on frames of the event
+	// sequence for the delivery hook
+	Type    string
+	Payload json.RawMessage
+}
+
+// Send queues an encoded message for the client without blocking. A client
+// whose buffer is full cannot keep up and is disconnected, so it does not hold
+// back everyone else. It reports whether the message was queued.
+func (c *Client) Send(data []byte) bool {
+	return c.SendFrame(Frame{Data: data})
+}
+
+// SendFrame is like Send for frames of the event sequence.
+func (c *Client) SendFrame(frame Frame) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.send <- frame:
+		return true
+	default:
+		c.hub.slowConsumers.Add(1)
+		c.Close(websocket.CloseTryAgainLater, "slow consumer")
+		return false
+	}
+}
+
+// Close sends a close frame with the code and reason and closes the
+// connection. The read loop then fails and unregisters the client. It is safe
+// to call more than once and from any goroutine.
+func (c *Client) Close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		// WriteControl may be called concurrently with the write pump
+		msg := websocket.FormatCloseMessage(code, reason)
+		_ = c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeGracePeriod))
+		_ = c.conn.Close()
+	})
+}
+
+// Replay writes missed frames, in order, before any live frame. It must be
+// called before ReadPump; live frames queued meanwhile are delivered after
+// it, minus the ones it already wrote.
+func (c *Client) Replay(frames []Frame) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout))
+	for _, frame := range frames {
+		if err := c.conn.WriteMessage(websocket.TextMessage, frame.Data); err != nil {
+			return err
+		}
+		if frame.Seq > c.replayedSeq {
+			c.replayedSeq = frame.Seq
+		}
+		c.delivered(frame)
+	}
+	return nil
+}
+
+// ReadPump calls handle with every message the client sends until the
+// connection fails or is closed, then unregisters the client. It blocks and
+// must be called from the goroutine that owns the upgraded connection.
+func (c *Client) ReadPump(handle func(data []byte)) error {
+	defer c.hub.unregister(c)
+
+	// Every frame, pongs included, pushes the read deadline out. A peer that
+	// stops answering pings, e.g. behind a half-open TCP connection, makes
+	// the next read fail.
+	c.conn.SetReadLimit(c.hub.maxMessageSize)
+	c.extendReadDeadline()
+	c.conn.SetPongHandler(func(string) error {
+		c.extendReadDeadline()
+		return nil
+	})
+	c.lastMessage.Store(time.Now().UnixNano())
+
+	go c.writePump()
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			c.countReaped(err)
+			return err
+		}
+		c.extendReadDeadline()
+		c.lastMessage.Store(time.Now().UnixNano())
+		handle(data)
+	}
+}
+
+// writePump is the only goroutine writing data frames to the connection. It
+// also sends the heartbeat pings and enforces the idle timeout.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.hub.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case frame := <-c.send:
+			if frame.Seq > 0 && frame.Seq <= c.replayedSeq {
+				continue
+			}
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, frame.Data); err != nil {
+				c.writeFailed("write failed")
+				return
+			}
+			c.delivered(frame)
+		case now := <-ticker.C:
+			if idle := now.Sub(time.Unix(0, c.lastMessage.Load())); idle >= c.hub.idleTimeout {
+				c.hub.reapedIdle.Add(1)
+				c.Close(websocket.CloseNormalClosure, "idle timeout")
+				return
+			}
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, now.Add(c.hub.writeTimeout)); err != nil {
+				c.writeFailed("ping failed")
+				return
+			}
+		}
+	}
+}
+
+// delivered passes a written frame of the event sequence to the delivery hook.
+func (c *Client) delivered(frame Frame) {
+	if frame.Type != "" && c.hub.deliveryHook != nil {
+		c.hub.deliveryHook(c.UserID, frame.Type, frame.Payload)
+	}
+}
+
+// writeFailed drops a connection the peer stopped reading from, unless the
+// write failed because the connection was being closed anyway.
+func (c *Client) writeFailed(reason string) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	c.hub.reapedHeartbeat.Add(1)
+	c.Close(websocket.CloseInternalServerErr, reason)
+}
+
+func (c *Client) extendReadDeadline() {
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.hub.pongTimeout))
+}
+
+// countReaped records why the server dropped a connection. Reads that fail
+// because the connection was closed on purpose are not counted.
+func (c *Client) countReaped(err error) {
+	if errors.Is(err, websocket.ErrReadLimit) {
+		c.hub.reapedOversized.Add(1)
+		c.Close(websocket.CloseMessageTooBig, "message too big")
+		return
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		c.hub.reapedHeartbeat.Add(1)
+		c.Close(websocket.CloseGoingAway, "heartbeat timeout")
+	}
+}
@@ -0,0 +1,425 @@
+// Package hub keeps track of live WebSocket connections and delivers messages
+// to them.
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+	"github.com/fasthttp/websocket"
+)
+
+// Hub holds every live client, indexed by user and by the chat rooms the
+// clients joined. It is safe for concurrent use.
+type Hub struct {
+	mu    sync.RWMutex
+	users map[int]map[*Client]struct{} // userID -> connections
+	rooms map[int]map[*Client]struct{} // chatID -> connections that joined
+
+	broker       Broker
+	deliveryHook DeliveryHook
+
+	typingMu sync.Mutex
+	typing   map[int]map[int]*typingState // chatID -> userID -> indicator
+
+	nodeID       string
+	presenceHook PresenceHook
+	presenceMu   sync.Mutex
+	nodes        map[string]*nodePresence // nodeID -> statuses of its users
+	announced    map[int]string           // userID -> status last settled
+	pending      map[int]time.Time        // userID -> last change, while debouncing
+
+	// reportMu is held while this node works out and publishes the status of
+	// its users, so reports cannot overtake each other. It is separate from
+	// presenceMu because the memory broker delivers the report synchronously.
+	reportMu sync.Mutex
+	reported map[int]string // userID -> status last published by this node
+
+	sendBuffer       int
+	pingInterval     time.Duration
+	pongTimeout      time.Duration
+	writeTimeout     time.Duration
+	idleTimeout      time.Duration
+	maxMessageSize   int64
+	typingTimeout    time.Duration
+	presenceDebounce time.Duration
+
+	brokerConnected atomic.Bool
+	slowConsumers   atomic.Int64
+	reapedHeartbeat atomic.Int64
+	reapedIdle      atomic.Int64
+	reapedOversized atomic.Int64
+}
+
+// The subscription to the broker is retried after brokerRetryMin at first,
+// doubling up to brokerRetryMax.
+const (
+	brokerRetryMin = time.Second
+	brokerRetryMax = 30 * time.Second
+)
+
+// DeliveryHook is called after a frame of a user's event sequence was
+// written to one of their connections, once per connection. It runs in the
+// connection's write loop, so it must not block.
+type DeliveryHook func(userID int, eventType string, payload json.RawMessage)
+
+// Stats is a snapshot of the hub for health checks.
+type Stats struct {
+	// BrokerConnected is false while the subscription to the broker is
+	// being retried; events from other nodes, and this one, are then lost.
+	BrokerConnected bool `json:"broker_connected"`
+
+	ConnectedUsers int   `json:"connected_users"`
+	Connections    int   `json:"connections"`
+	ActiveRooms    int   `json:"active_rooms"`
+	SlowConsumers  int64 `json:"slow_consumers_disconnected"`
+	// Connections dropped for missing pongs or stalled writes, for not sending
+	// anything within the idle timeout, and for oversized messages.
+	ReapedHeartbeat int64 `json:"reaped_heartbeat"`
+	ReapedIdle      int64 `json:"reaped_idle"`
+	ReapedOversized int64 `json:"reaped_oversized"`
+}
+
+// New creates a hub with the connection limits and heartbeat settings of cfg.
+// Everything sent through the hub goes through the broker, so it reaches the
+// connections on every node.
+func New(cfg config.WebSocketConfig, broker Broker) (*Hub, error) {
+	if cfg.PingInterval <= 0 || cfg.PongTimeout <= cfg.PingInterval {
+		return nil, fmt.Errorf("websocket pong_timeout (%ds) must be greater than ping_interval (%ds)", cfg.PongTimeout, cfg.PingInterval)
+	}
+	if cfg.SendBuffer <= 0 || cfg.MaxMessageSize <= 0 || cfg.WriteTimeout <= 0 || cfg.IdleTimeout <= 0 || cfg.TypingTimeout <= 0 {
+		return nil, fmt.Errorf("websocket send_buffer, max_message_size, write_timeout, idle_timeout and typing_timeout must be positive")
+	}
+	if cfg.PresenceDebounce < 0 {
+		return nil, fmt.Errorf("websocket presence_debounce must not be negative")
+	}
+
+	return &Hub{
+		users:            make(map[int]map[*Client]struct{}),
+		rooms:            make(map[int]map[*Client]struct{}),
+		typing:           make(map[int]map[int]*typingState),
+		nodeID:           newNodeID(),
+		nodes:            make(map[string]*nodePresence),
+		reported:         make(map[int]string),
+		announced:        make(map[int]string),
+		pending:          make(map[int]time.Time),
+		broker:           broker,
+		sendBuffer:       cfg.SendBuffer,
+		pingInterval:     time.Duration(cfg.PingInterval) * time.Second,
+		pongTimeout:      time.Duration(cfg.PongTimeout) * time.Second,
+		writeTimeout:     time.Duration(cfg.WriteTimeout) * time.Second,
+		idleTimeout:      time.Duration(cfg.IdleTimeout) * time.Minute,
+		maxMessageSize:   cfg.MaxMessageSize,
+		typingTimeout:    time.Duration(cfg.TypingTimeout) * time.Second,
+		presenceDebounce: time.Duration(cfg.PresenceDebounce) * time.Second,
+	}, nil
+}
+
+// OnDelivered registers the hook that learns which events reached a user. It
+// must be called before Run.
+func (h *Hub) OnDelivered(hook DeliveryHook) {
+	h.deliveryHook = hook
+}
+
+// Register adds a freshly upgraded connection. The caller must then run the
+// client's ReadPump.
+func (h *Hub) Register(conn *websocket.Conn, userID int, username string, sessionID, version int) *Client {
+	c := &Client{
+		hub:       h,
+		conn:      conn,
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+		Version:   version,
+		send:      make(chan Frame, h.sendBuffer),
+		done:      make(chan struct{}),
+
+		typingAnnounced: make(map[int]time.Time),
+	}
+
+	h.mu.Lock()
+	if h.users[userID] == nil {
+		h.users[userID] = make(map[*Client]struct{})
+	}
+	h.users[userID][c] = struct{}{}
+	h.mu.Unlock()
+
+	h.localPresenceChanged(userID)
+
+	return c
+}
+
+func (h *Hub) unregister(c *Client) {
+	c.Close(websocket.CloseNormalClosure, "")
+	defer h.localPresenceChanged(c.UserID)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if clients := h.users[c.UserID]; clients != nil {
+		delete(clients, c)
+		if len(clients) == 0 {
+			delete(h.users, c.UserID)
+		}
+	}
+	for chatID, clients := range h.rooms {
+		delete(clients, c)
+		if len(clients) == 0 {
+			delete(h.rooms, chatID)
+		}
+	}
+}
+
+// Join subscribes the client to a chat room.
+func (h *Hub) Join(c *Client, chatID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.rooms[chatID] == nil {
+		h.rooms[chatID] = make(map[*Client]struct{})
+	}
+	h.rooms[chatID][c] = struct{}{}
+}
+
+// Leave unsubscribes the client from a chat room.
+func (h *Hub) Leave(c *Client, chatID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if clients := h.rooms[chatID]; clients != nil {
+		delete(clients, c)
+		if len(clients) == 0 {
+			delete(h.rooms, chatID)
+		}
+	}
+}
+
+// message is what hubs exchange through the broker. It either delivers an
+// event to the local connections of users, announces a
+// typing indicator to users, reports the presence of the users of a node, or
+// closes the local connections of sessions.
+type message struct {
+	Type    string          `json:"type,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	UserIDs []int           `json:"user_ids,omitempty"`
+	Seqs    map[int]int64   `json:"seqs,omitempty"` // logged events: user ID -> sequence number
+
+	Typing   *typingMessage   `json:"typing,omitempty"`
+	Presence *presenceMessage `json:"presence,omitempty"`
+
+	CloseSessions []int  `json:"close_sessions,omitempty"`
+	CloseCode     int    `json:"close_code,omitempty"`
+	CloseReason   string `json:"close_reason,omitempty"`
+}
+
+// Run receives the messages of every node from the broker and delivers them
+// to the local connections until ctx is cancelled. A subscription that fails
+// is retried with exponential backoff; meanwhile Stats reports the broker as
+// disconnected. It also expires typing indicators and keeps the other nodes
+// up to date about presence.
+func (h *Hub) Run(ctx context.Context) {
+	go h.expireTyping(ctx)
+	go h.syncPresence(ctx)
+
+	delay := brokerRetryMin
+	for {
+		started := time.Now()
+		err := h.broker.Subscribe(ctx, func(data []byte) {
+			var msg message
+			if err := json.Unmarshal(data, &msg); err != nil {
+				log.Printf("Invalid hub message: %v", err)
+				return
+			}
+			h.deliverLocal(msg)
+		}, h.brokerConnected.Store)
+		h.brokerConnected.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A subscription that held for a while starts over with short delays
+		if time.Since(started) > brokerRetryMax {
+			delay = brokerRetryMin
+		}
+		log.Printf("WebSocket broker subscription lost, retrying in %s: %v", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, brokerRetryMax)
+	}
+}
+
+// SendToUsers delivers an event that is not logged to every connection of
+// the given users, on every node.
+func (h *Hub) SendToUsers(ctx context.Context, userIDs []int, eventType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return h.publish(ctx, message{Type: eventType, Payload: data, UserIDs: userIDs})
+}
+
+// SendEvent delivers a logged event to every connection of the users in seqs,
+// each with their own sequence number, on every node.
+func (h *Hub) SendEvent(ctx context.Context, eventType string, payload json.RawMessage, seqs map[int]int64) error {
+	return h.publish(ctx, message{Type: eventType, Payload: payload, Seqs: seqs})
+}
+
+// CloseSessions disconnects every connection authenticated with one of the
+// given sessions, on every node.
+func (h *Hub) CloseSessions(ctx context.Context, sessionIDs []int, code int, reason string) error {
+	return h.publish(ctx, message{CloseSessions: sessionIDs, CloseCode: code, CloseReason: reason})
+}
+
+func (h *Hub) publish(ctx context.Context, msg message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode hub message: %w", err)
+	}
+	return h.broker.Publish(ctx, data)
+}
+
+// deliverLocal hands a message to the connections of this node.
+func (h *Hub) deliverLocal(msg message) {
+	if len(msg.CloseSessions) > 0 {
+		h.closeLocalSessions(msg.CloseSessions, msg.CloseCode, msg.CloseReason)
+		return
+	}
+	if msg.Typing != nil {
+		h.deliverTyping(msg)
+		return
+	}
+	if msg.Presence != nil {
+		h.deliverPresence(msg.Presence)
+		return
+	}
+
+	// Frames are encoded once per protocol version and sequence number
+	type frameKey struct {
+		version int
+		seq     int64
+	}
+	encoded := make(map[frameKey][]byte)
+	encode := func(version int, seq int64) []byte {
+		key := frameKey{version: version, seq: seq}
+		if data, ok := encoded[key]; ok {
+			return data
+		}
+		data, err := EncodeFrame(version, msg.Type, msg.Payload, seq)
+		if err != nil {
+			log.Printf("Error encoding %s frame: %v", msg.Type, err)
+		}
+		encoded[key] = data
+		return data
+	}
+
+	h.mu.RLock()
+	var targets []*Client
+	var frames []Frame
+	add := func(c *Client, frame Frame) {
+		if frame.Data == nil {
+			return
+		}
+		targets = append(targets, c)
+		frames = append(frames, frame)
+	}
+	switch {
+	case len(msg.Seqs) > 0:
+		for userID, seq := range msg.Seqs {
+			for c := range h.users[userID] {
+				add(c, Frame{Data: encode(c.Version, seq), Seq: seq, Type: msg.Type, Payload: msg.Payload})
+			}
+		}
+	default:
+		for _, userID := range msg.UserIDs {
+			for c := range h.users[userID] {
+				add(c, Frame{Data: encode(c.Version, 0)})
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	// Slow clients are closed, and unregistered, on the way, so this runs
+	// outside the hub lock
+	for i, c := range targets {
+		c.SendFrame(frames[i])
+	}
+}
+
+func (h *Hub) closeLocalSessions(sessionIDs []int, code int, reason string) {
+	closing := make(map[int]bool, len(sessionIDs))
+	for _, id := range sessionIDs {
+		closing[id] = true
+	}
+
+	h.mu.RLock()
+	var targets []*Client
+	for _, clients := range h.users {
+		for c := range clients {
+			if closing[c.SessionID] {
+				targets = append(targets, c)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		c.Close(code, reason)
+	}
+}
+
+// legacyFrameTypes are the names events had in protocol version 1.
+var legacyFrameTypes = map[string]string{
+	"message.created": "message",
+	"chat.created":    "new_chat",
+}
+
+// FrameType returns the name of a server frame type in a protocol version.
+func FrameType(version int, frameType string) string {
+	if legacy, ok := legacyFrameTypes[frameType]; ok && version < 2 {
+		return legacy
+	}
+	return frameType
+}
+
+// EncodeFrame builds a server frame of a version of the WebSocket protocol.
+func EncodeFrame(version int, eventType string, payload json.RawMessage, seq int64) ([]byte, error) {
+	return json.Marshal(model.WSMessage{
+		V:       version,
+		Type:    FrameType(version, eventType),
+		Seq:     seq,
+		Payload: payload,
+	})
+}
+
+// Stats returns the current counters of the hub.
+func (h *Hub) Stats() Stats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	connections := 0
+	for _, clients := range h.users {
+		connections += len(clients)
+	}
+
+	return Stats{
+		BrokerConnected: h.brokerConnected.Load(),
+		ConnectedUsers:  len(h.users),
+		Connections:     connections,
+		ActiveRooms:     len(h.rooms),
+		SlowConsumers:   h.slowConsumers.Load(),
+		ReapedHeartbeat: h.reapedHeartbeat.Load(),
+		ReapedIdle:      h.reapedIdle.Load(),
+		ReapedOversized: h.reapedOversized.Load(),
+	}
+}
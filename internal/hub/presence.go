@@ -0,0 +1,286 @@
+package hub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+)
+
+// Presence statuses. A user is online while any of their connections is
+// active, away while all of them are away, and offline without connections.
+const (
+	StatusOnline  = "online"
+	StatusAway    = "away"
+	StatusOffline = "offline"
+)
+
+const (
+	// presenceSyncInterval is how often every node publishes the presence of
+	// all its users. Nodes that missed presenceNodeTimeout worth of them are
+	// considered gone, together with their users.
+	presenceSyncInterval = 15 * time.Second
+	presenceNodeTimeout  = 3 * presenceSyncInterval
+)
+
+// PresenceHook is called when the status of a user changed and stayed
+// changed for the debounce period. at is when it last changed. Only one node
+// of the cluster calls it for a change.
+type PresenceHook func(userID int, status string, at time.Time)
+
+// presenceMessage carries the statuses of users on one node. A snapshot
+// replaces everything known about the node and holds no offline users.
+type presenceMessage struct {
+	Node     string         `json:"node"`
+	Statuses map[int]string `json:"statuses"`
+	Snapshot bool           `json:"snapshot,omitempty"`
+}
+
+// nodePresence is what a node last reported about its users.
+type nodePresence struct {
+	statuses map[int]string
+	seen     time.Time
+}
+
+func newNodeID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// OnPresenceChange registers the hook that announces status changes. It must
+// be called before Run.
+func (h *Hub) OnPresenceChange(hook PresenceHook) {
+	h.presenceHook = hook
+}
+
+// SetAway marks one connection as away, e.g. while its tab is hidden, or
+// active again.
+func (h *Hub) SetAway(c *Client, away bool) {
+	c.away.Store(away)
+	h.localPresenceChanged(c.UserID)
+}
+
+// Status returns the status of a user across every node.
+func (h *Hub) Status(userID int) string {
+	h.presenceMu.Lock()
+	defer h.presenceMu.Unlock()
+	return h.globalStatus(userID)
+}
+
+// localStatus derives the status of a user from the connections of this node.
+func (h *Hub) localStatus(userID int) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	status := StatusOffline
+	for c := range h.users[userID] {
+		if !c.away.Load() {
+			return StatusOnline
+		}
+		status = StatusAway
+	}
+	return status
+}
+
+// localPresenceChanged tells every node about the new status of a user on
+// this node, if it changed. The status is read and published under reportMu,
+// so concurrent changes are published in the order they were read and the
+// last one wins.
+func (h *Hub) localPresenceChanged(userID int) {
+	h.reportMu.Lock()
+	defer h.reportMu.Unlock()
+
+	status := h.localStatus(userID)
+	previous, ok := h.reported[userID]
+	if !ok {
+		previous = StatusOffline
+	}
+	if status == previous {
+		return
+	}
+	if status == StatusOffline {
+		delete(h.reported, userID)
+	} else {
+		h.reported[userID] = status
+	}
+
+	if err := h.publish(context.Background(), message{Presence: &presenceMessage{
+		Node:     h.nodeID,
+		Statuses: map[int]string{userID: status},
+	}}); err != nil {
+		log.Printf("Error publishing presence of user %d: %v", userID, err)
+	}
+}
+
+// deliverPresence records the statuses a node reported and debounces the
+// changes they cause.
+func (h *Hub) deliverPresence(msg *presenceMessage) {
+	h.presenceMu.Lock()
+	defer h.presenceMu.Unlock()
+
+	node := h.nodes[msg.Node]
+	if node == nil {
+		node = &nodePresence{statuses: make(map[int]string)}
+		h.nodes[msg.Node] = node
+	}
+	node.seen = time.Now()
+
+	affected := make(map[int]string)
+	for userID := range msg.Statuses {
+		affected[userID] = h.globalStatus(userID)
+	}
+	if msg.Snapshot {
+		for userID := range node.statuses {
+			if _, ok := affected[userID]; !ok {
+				affected[userID] = h.globalStatus(userID)
+			}
+		}
+		node.statuses = make(map[int]string, len(msg.Statuses))
+	}
+
+	for userID, status := range msg.Statuses {
+		if status == StatusOffline {
+			delete(node.statuses, userID)
+		} else {
+			node.statuses[userID] = status
+		}
+	}
+
+	for userID, before := range affected {
+		if h.globalStatus(userID) != before {
+			h.schedulePresence(userID)
+		}
+	}
+}
+
+// globalStatus combines the statuses of a user on every live node. It must be
+// called with presenceMu held.
+func (h *Hub) globalStatus(userID int) string {
+	status := StatusOffline
+	for _, node := range h.nodes {
+		switch node.statuses[userID] {
+		case StatusOnline:
+			return StatusOnline
+		case StatusAway:
+			status = StatusAway
+		}
+	}
+	return status
+}
+
+// schedulePresence settles the status of a user once it stopped changing
+// for the debounce period, so a reload does not announce offline and online.
+// It must be called with presenceMu held.
+func (h *Hub) schedulePresence(userID int) {
+	_, pending := h.pending[userID]
+	h.pending[userID] = time.Now()
+	if pending {
+		return
+	}
+	time.AfterFunc(h.presenceDebounce, func() { h.settlePresence(userID) })
+}
+
+func (h *Hub) settlePresence(userID int) {
+	h.presenceMu.Lock()
+	changedAt := h.pending[userID]
+	delete(h.pending, userID)
+
+	status := h.globalStatus(userID)
+	announced, ok := h.announced[userID]
+	if !ok {
+		announced = StatusOffline
+	}
+	if status == announced {
+		h.presenceMu.Unlock()
+		return
+	}
+	if status == StatusOffline {
+		delete(h.announced, userID)
+	} else {
+		h.announced[userID] = status
+	}
+	leader := h.isPresenceLeader()
+	h.presenceMu.Unlock()
+
+	if leader && h.presenceHook != nil {
+		h.presenceHook(userID, status, changedAt)
+	}
+}
+
+// isPresenceLeader reports whether this node announces presence changes: the
+// live node with the lowest ID. It must be called with presenceMu held.
+func (h *Hub) isPresenceLeader() bool {
+	for id := range h.nodes {
+		if id < h.nodeID {
+			return false
+		}
+	}
+	return true
+}
+
+// syncPresence publishes the presence of the users on this node and forgets
+// nodes that stopped doing so, until ctx is cancelled.
+func (h *Hub) syncPresence(ctx context.Context) {
+	ticker := time.NewTicker(presenceSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		h.publishPresenceSnapshot(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			h.presenceMu.Lock()
+			for id, node := range h.nodes {
+				if id == h.nodeID || now.Sub(node.seen) < presenceNodeTimeout {
+					continue
+				}
+				before := make(map[int]string, len(node.statuses))
+				for userID := range node.statuses {
+					before[userID] = h.globalStatus(userID)
+				}
+				delete(h.nodes, id)
+				for userID, status := range before {
+					if h.globalStatus(userID) != status {
+						h.schedulePresence(userID)
+					}
+				}
+			}
+			h.presenceMu.Unlock()
+		}
+	}
+}
+
+func (h *Hub) publishPresenceSnapshot(ctx context.Context) {
+	h.reportMu.Lock()
+	defer h.reportMu.Unlock()
+
+	h.mu.RLock()
+	userIDs := make([]int, 0, len(h.users))
+	for userID := range h.users {
+		userIDs = append(userIDs, userID)
+	}
+	h.mu.RUnlock()
+
+	statuses := make(map[int]string, len(userIDs))
+	reported := make(map[int]string, len(userIDs))
+	for _, userID := range userIDs {
+		if status := h.localStatus(userID); status != StatusOffline {
+			statuses[userID] = status
+			reported[userID] = status
+		}
+	}
+
+	h.reported = reported
+
+	if err := h.publish(ctx, message{Presence: &presenceMessage{
+		Node:     h.nodeID,
+		Statuses: statuses,
+		Snapshot: true,
+	}}); err != nil {
+		log.Printf("Error publishing presence snapshot: %v", err)
+	}
+}
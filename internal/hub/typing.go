@@ -0,0 +1,175 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/model"
+)
+
+// Server frame types of typing indicators
+const (
+	TypingStart = "typing.start"
+	TypingStop  = "typing.stop"
+)
+
+// typingSweepInterval is how often expired typing indicators are stopped.
+const typingSweepInterval = time.Second
+
+// typingMessage announces that a user started or stopped typing. Every node
+// keeps track of who is typing, so each can answer who is typing in a chat
+// and stop indicators for its own connections when they expire.
+type typingMessage struct {
+	ChatID   int    `json:"chat_id"`
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Active   bool   `json:"active"`
+}
+
+// typingState is a user typing in a chat and who was told about it.
+type typingState struct {
+	username   string
+	recipients []int
+	expires    time.Time
+}
+
+// SetTyping tells the recipients, on every node, that the client's user
+// started or stopped typing in the chat. A start lasts for the typing timeout
+// unless it is repeated; see TypingThrottled.
+func (h *Hub) SetTyping(ctx context.Context, c *Client, chatID int, active bool, recipients []int) error {
+	if active {
+		c.typingAnnounced[chatID] = time.Now()
+	} else {
+		delete(c.typingAnnounced, chatID)
+	}
+
+	return h.publish(ctx, message{
+		UserIDs: recipients,
+		Typing: &typingMessage{
+			ChatID:   chatID,
+			UserID:   c.UserID,
+			Username: c.Username,
+			Active:   active,
+		},
+	})
+}
+
+// TypingThrottled reports whether the client announced typing in the chat
+// too recently to announce it again. It must be called from the client's
+// read loop.
+func (c *Client) TypingThrottled(chatID int) bool {
+	announced, ok := c.typingAnnounced[chatID]
+	return ok && time.Since(announced) < c.hub.typingTimeout/2
+}
+
+// Typing returns who is typing in the chat, ordered by user ID.
+func (h *Hub) Typing(chatID int) []model.WSTypingPayload {
+	h.typingMu.Lock()
+	defer h.typingMu.Unlock()
+
+	now := time.Now()
+	typing := make([]model.WSTypingPayload, 0, len(h.typing[chatID]))
+	for userID, state := range h.typing[chatID] {
+		if now.Before(state.expires) {
+			typing = append(typing, model.WSTypingPayload{
+				ChatID:   chatID,
+				UserID:   userID,
+				Username: state.username,
+			})
+		}
+	}
+	sort.Slice(typing, func(i, j int) bool { return typing[i].UserID < typing[j].UserID })
+	return typing
+}
+
+// deliverTyping records a typing message and passes it on to the local
+// connections of its recipients. A stop for a user who is not typing is
+// dropped.
+func (h *Hub) deliverTyping(msg message) {
+	t := msg.Typing
+
+	h.typingMu.Lock()
+	users := h.typing[t.ChatID]
+	_, wasTyping := users[t.UserID]
+	if t.Active {
+		if users == nil {
+			users = make(map[int]*typingState)
+			h.typing[t.ChatID] = users
+		}
+		users[t.UserID] = &typingState{
+			username:   t.Username,
+			recipients: msg.UserIDs,
+			expires:    time.Now().Add(h.typingTimeout),
+		}
+	} else {
+		delete(users, t.UserID)
+		if len(users) == 0 {
+			delete(h.typing, t.ChatID)
+		}
+	}
+	h.typingMu.Unlock()
+
+	if !t.Active && !wasTyping {
+		return
+	}
+	h.sendTypingFrame(t.ChatID, t.UserID, t.Username, t.Active, msg.UserIDs)
+}
+
+// expireTyping stops typing indicators that were not repeated in time, until
+// ctx is cancelled.
+func (h *Hub) expireTyping(ctx context.Context) {
+	ticker := time.NewTicker(typingSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			type expired struct {
+				chatID, userID int
+				state          *typingState
+			}
+			var stopped []expired
+
+			h.typingMu.Lock()
+			for chatID, users := range h.typing {
+				for userID, state := range users {
+					if !now.Before(state.expires) {
+						stopped = append(stopped, expired{chatID, userID, state})
+						delete(users, userID)
+					}
+				}
+				if len(users) == 0 {
+					delete(h.typing, chatID)
+				}
+			}
+			h.typingMu.Unlock()
+
+			for _, e := range stopped {
+				h.sendTypingFrame(e.chatID, e.userID, e.state.username, false, e.state.recipients)
+			}
+		}
+	}
+}
+
+func (h *Hub) sendTypingFrame(chatID, userID int, username string, active bool, recipients []int) {
+	frameType := TypingStop
+	if active {
+		frameType = TypingStart
+	}
+
+	payload, err := json.Marshal(model.WSTypingPayload{
+		ChatID:   chatID,
+		UserID:   userID,
+		Username: username,
+	})
+	if err != nil {
+		log.Printf("Error encoding %s frame: %v", frameType, err)
+		return
+	}
+	h.deliverLocal(message{Type: frameType, Payload: payload, UserIDs: recipients})
+}
@@ -1,13 +1,18 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"strings"
 
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
 	"github.com/gofiber/fiber/v3"
 )
 
-func AuthMiddleware(authService *auth.Service) fiber.Handler {
+// AuthMiddleware accepts "Bearer <access token>" from user sessions and
+// "Bot <api token>" from bot accounts.
+func AuthMiddleware(authService *auth.Service, sessionService *service.SessionService, botService *service.BotService) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -17,12 +22,16 @@ func AuthMiddleware(authService *auth.Service) fiber.Handler {
 		}
 
 		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
+		if len(parts) != 2 || (parts[0] != "Bearer" && parts[0] != "Bot") {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "invalid authorization header format",
 			})
 		}
 
+		if parts[0] == "Bot" {
+			return authenticateBot(c, botService, parts[1])
+		}
+
 		token := parts[1]
 		payload, err := authService.VerifyToken(token)
 		if err != nil {
@@ -31,10 +40,81 @@ func AuthMiddleware(authService *auth.Service) fiber.Handler {
 			})
 		}
 
+		// Reject tokens whose session was revoked or has expired
+		active, err := sessionService.IsSessionActive(context.Background(), payload.SessionID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "failed to check session",
+			})
+		}
+		if !active {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "session has been revoked",
+			})
+		}
+
 		// Store user info in context
 		c.Locals("user_id", payload.UserID)
 		c.Locals("username", payload.Username)
+		c.Locals("session_id", payload.SessionID)
+		c.Locals("is_bot", false)
+
+		return c.Next()
+	}
+}
+
+func authenticateBot(c fiber.Ctx, botService *service.BotService, token string) error {
+	apiToken, err := botService.Authenticate(context.Background(), token)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIToken) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "invalid, expired or revoked api token",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to check api token",
+		})
+	}
+
+	// Bots have no session
+	c.Locals("user_id", apiToken.Edges.User.ID)
+	c.Locals("username", apiToken.Edges.User.Username)
+	c.Locals("session_id", 0)
+	c.Locals("is_bot", true)
+	c.Locals("scopes", apiToken.Scopes)
+
+	return c.Next()
+}
+
+// RequireScope rejects bots whose API token lacks the scope. User sessions
+// are not limited by scopes.
+func RequireScope(scope string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if isBot, _ := c.Locals("is_bot").(bool); !isBot {
+			return c.Next()
+		}
 
+		scopes, _ := c.Locals("scopes").([]string)
+		for _, s := range scopes {
+			if s == scope {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "api token is missing the " + scope + " scope",
+		})
+	}
+}
+
+// RequireUser rejects bots, e.g. from account management endpoints.
+func RequireUser() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if isBot, _ := c.Locals("is_bot").(bool); isBot {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "not available to bots",
+			})
+		}
 		return c.Next()
 	}
 }
@@ -1,22 +1,125 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Auth models
 type LoginRequest struct {
-	Username string `json:"username" form:"username" validate:"required"`
-	Password string `json:"password" form:"password" validate:"required"`
+	Username   string `json:"username" form:"username" validate:"required"`
+	Password   string `json:"password" form:"password" validate:"required"`
+	DeviceName string `json:"device_name,omitempty" form:"device_name" validate:"max=100"`
 }
 
 type RegisterRequest struct {
 	Username    string `json:"username" form:"username" validate:"required,min=3,max=50"`
-	Password    string `json:"password" form:"password" validate:"required,min=6"`
+	Password    string `json:"password" form:"password" validate:"required"`
 	DisplayName string `json:"display_name,omitempty" form:"display_name"`
+	Email       string `json:"email,omitempty" form:"email" validate:"omitempty,email,max=254"`
+	DeviceName  string `json:"device_name,omitempty" form:"device_name" validate:"max=100"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token" validate:"required"`
 }
 
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  UserProfile `json:"user"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refresh_token"`
+	ExpiresAt    time.Time   `json:"expires_at"`
+	User         UserProfile `json:"user"`
+}
+
+type TokenResponse struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// MFAChallengeResponse is returned by the password step of a login when the
+// user has two-factor authentication enabled.
+type MFAChallengeResponse struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type MFALoginRequest struct {
+	MFAToken   string `json:"mfa_token" form:"mfa_token" validate:"required"`
+	Code       string `json:"code" form:"code" validate:"required"`
+	DeviceName string `json:"device_name,omitempty" form:"device_name" validate:"max=100"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" form:"code" validate:"required"`
+}
+
+type DisableMFARequest struct {
+	Password string `json:"password" form:"password" validate:"required"`
+	Code     string `json:"code" form:"code" validate:"required"`
+}
+
+type TOTPSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" form:"token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" form:"email" validate:"required,email,max=254"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" form:"token" validate:"required"`
+	NewPassword string `json:"new_password" form:"new_password" validate:"required"`
+}
+
+type OIDCProviderResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+type OIDCLinkResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type IdentityResponse struct {
+	ID          int        `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+type SessionResponse struct {
+	ID         int       `json:"id"`
+	DeviceName string    `json:"device_name,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type PublicKeyResponse struct {
+	KeyID     string `json:"kid"`
+	Version   string `json:"version"`
+	Purpose   string `json:"purpose"`
+	PublicKey string `json:"public_key"`
+	Active    bool   `json:"active"`
+}
+
+type PublicKeysResponse struct {
+	Keys []PublicKeyResponse `json:"keys"`
 }
 
 // User models
@@ -24,13 +127,77 @@ type UserProfile struct {
 	ID          int        `json:"id"`
 	Username    string     `json:"username"`
 	DisplayName string     `json:"display_name,omitempty"`
+	IsBot       bool       `json:"is_bot,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	LastSeen    *time.Time `json:"last_seen,omitempty"`
+	// Only set on the user's own profile
+	Email            *string `json:"email,omitempty"`
+	EmailVerified    *bool   `json:"email_verified,omitempty"`
+	HideLastSeen     *bool   `json:"hide_last_seen,omitempty"`
+	HideReadReceipts *bool   `json:"hide_read_receipts,omitempty"`
 }
 
 type UpdateUserRequest struct {
-	DisplayName string `json:"display_name,omitempty" form:"display_name"`
-	Password    string `json:"password,omitempty" form:"password"`
+	DisplayName      string `json:"display_name,omitempty" form:"display_name"`
+	Email            string `json:"email,omitempty" form:"email" validate:"omitempty,email,max=254"`
+	HideLastSeen     *bool  `json:"hide_last_seen,omitempty" form:"hide_last_seen"`
+	HideReadReceipts *bool  `json:"hide_read_receipts,omitempty" form:"hide_read_receipts"`
+	// Password is only accepted to reject it, see ChangePasswordRequest
+	Password string `json:"password,omitempty" form:"password"`
+}
+
+// PresenceResponse is the status of a user. LastSeen is only set for offline
+// users who do not hide it.
+type PresenceResponse struct {
+	UserID   int        `json:"user_id"`
+	Status   string     `json:"status"` // online, away or offline
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+// ChangePasswordRequest sets a new password. CurrentPassword is required
+// unless the account has no password yet, e.g. after single sign-on. Such
+// accounts give a two-factor Code instead when 2FA is enabled.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" form:"current_password"`
+	NewPassword     string `json:"new_password" form:"new_password" validate:"required"`
+	Code            string `json:"code,omitempty" form:"code"`
+}
+
+// DeleteUserRequest re-authenticates the user before the account is deleted.
+// Password is required unless the account has none, e.g. after single
+// sign-on. Code is required when two-factor authentication is enabled.
+type DeleteUserRequest struct {
+	Password string `json:"password" form:"password"`
+	Code     string `json:"code,omitempty" form:"code"`
+}
+
+// Bot models
+type CreateBotRequest struct {
+	Username    string `json:"username" form:"username" validate:"required,min=3,max=50"`
+	DisplayName string `json:"display_name,omitempty" form:"display_name" validate:"max=100"`
+}
+
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" form:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" form:"scopes" validate:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty" form:"expires_in_days" validate:"omitempty,min=1,max=3650"`
+}
+
+type APITokenResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedAPITokenResponse is the only response that contains the plain token.
+type CreatedAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
 }
 
 // Chat models
@@ -58,6 +225,22 @@ type ChatDetailResponse struct {
 	Members []ChatMemberResponse `json:"members"`
 }
 
+// ChatSummaryResponse is a chat in the chat list.
+type ChatSummaryResponse struct {
+	ChatDetailResponse
+	LastMessage *MessagePreviewResponse `json:"last_message"`
+	UnreadCount int                     `json:"unread_count"`
+}
+
+// MessagePreviewResponse is the start of the last message of a chat.
+type MessagePreviewResponse struct {
+	ID             int       `json:"id"`
+	SenderID       int       `json:"sender_id"`
+	SenderUsername string    `json:"sender_username"`
+	Snippet        string    `json:"snippet"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 type ChatMemberResponse struct {
 	UserID   int       `json:"user_id"`
 	Username string    `json:"username"`
@@ -69,10 +252,23 @@ type AddMembersRequest struct {
 	MemberIDs []int `json:"member_ids" form:"member_ids" validate:"required,min=1"`
 }
 
+// MarkReadRequest moves the read cursor of the current user up to a message.
+type MarkReadRequest struct {
+	MessageID int `json:"message_id" form:"message_id" validate:"required"`
+}
+
+type ReadCursorResponse struct {
+	ChatID            int        `json:"chat_id"`
+	LastReadMessageID *int       `json:"last_read_message_id"`
+	LastReadAt        *time.Time `json:"last_read_at"`
+}
+
 // Message models
 type SendMessageRequest struct {
 	Content string `json:"content" form:"content" validate:"required"`
 	ChatID  int    `json:"chat_id" form:"chat_id" validate:"required"`
+	// ReplyToID quotes a message of the same chat
+	ReplyToID int `json:"reply_to_id" form:"reply_to_id" validate:"omitempty,min=1"`
 }
 
 type MessageResponse struct {
@@ -84,16 +280,168 @@ type MessageResponse struct {
 	CreatedAt time.Time    `json:"created_at"`
 	UpdatedAt time.Time    `json:"updated_at"`
 	Sender    *UserProfile `json:"sender,omitempty"`
+	// ReplyToID and ReplyTo are the quoted message, left out once it was
+	// deleted
+	ReplyToID *int                    `json:"reply_to_id,omitempty"`
+	ReplyTo   *MessagePreviewResponse `json:"reply_to,omitempty"`
+	// Delivery, ReplyCount and LastReplyAt are only set on messages read
+	// back from the chat
+	Delivery    *MessageDeliveryResponse `json:"delivery,omitempty"`
+	ReplyCount  int                      `json:"reply_count"`
+	LastReplyAt *time.Time               `json:"last_reply_at,omitempty"`
+	// Reactions are only set on messages read back from the chat
+	Reactions []ReactionSummaryResponse `json:"reactions,omitempty"`
+}
+
+// ThreadResponse is a message with a page of its replies.
+type ThreadResponse struct {
+	Root    MessageResponse   `json:"root"`
+	Replies []MessageResponse `json:"replies"`
+}
+
+// Delivery states of a message
+const (
+	MessageStatusSent      = "sent"      // not yet delivered to every other member
+	MessageStatusDelivered = "delivered" // delivered to every other member
+	MessageStatusRead      = "read"      // read by every other member
+)
+
+// MessageDeliveryResponse is how far a message got with the other members of
+// its chat. Members who hide their read receipts are never counted as readers.
+type MessageDeliveryResponse struct {
+	Status         string `json:"status"`
+	RecipientCount int    `json:"recipient_count"`
+	DeliveredCount int    `json:"delivered_count"`
+	ReadCount      int    `json:"read_count"`
 }
 
 type UpdateMessageRequest struct {
 	Content string `json:"content" form:"content" validate:"required"`
 }
 
+// MessageReaderResponse is a member who read a message. ReadAt is when they
+// last moved their read cursor, at or past the message.
+type MessageReaderResponse struct {
+	UserID   int       `json:"user_id"`
+	Username string    `json:"username"`
+	ReadAt   time.Time `json:"read_at"`
+}
+
+// ReactionRequest adds or removes a reaction: a Unicode emoji, or a custom
+// emoji of the chat as :name:.
+type ReactionRequest struct {
+	Emoji string `json:"emoji" form:"emoji" validate:"required,max=64"`
+}
+
+// ReactionSummaryResponse is how many members reacted to a message with an
+// emoji, and whether the current user is one of them.
+type ReactionSummaryResponse struct {
+	Emoji       string `json:"emoji"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+// ReactionResponse is a member who reacted to a message.
+type ReactionResponse struct {
+	Emoji     string    `json:"emoji"`
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateChatEmojiRequest adds a custom emoji to a chat, used as :name:.
+type CreateChatEmojiRequest struct {
+	Name     string `json:"name" form:"name" validate:"required,min=2,max=32"`
+	ImageURL string `json:"image_url" form:"image_url" validate:"required,https_url,max=2048"`
+}
+
+type ChatEmojiResponse struct {
+	Name      string    `json:"name"`
+	ImageURL  string    `json:"image_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TypingUserResponse is a user currently typing in a chat.
+type TypingUserResponse struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+}
+
 // WebSocket models
+type WSTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// WSProtocolVersion is the newest WebSocket protocol version. Clients pick a
+// version when connecting and every frame carries it in "v". Version 2
+// renamed the "message" and "new_chat" events to "message.created" and
+// "chat.created".
+const WSProtocolVersion = 2
+
+// WSMinProtocolVersion is the oldest protocol version clients may still pick.
+const WSMinProtocolVersion = 1
+
+// Error codes sent in WebSocket error frames
+const (
+	WSErrorInvalidFrame       = "invalid_frame"       // not a JSON envelope
+	WSErrorUnsupportedVersion = "unsupported_version" // "v" differs from the negotiated version
+	WSErrorUnknownType        = "unknown_type"
+	WSErrorInvalidPayload     = "invalid_payload" // payload does not match the schema of the type
+	WSErrorNotMember          = "not_member"
+	WSErrorInternal           = "internal_error"
+)
+
+// WSMessage is the envelope of every frame the server sends. ID echoes the
+// client's request ID in ack and error frames and is empty in events. Seq is
+// the event's number in the user's event sequence; frames without it are not
+// replayed.
 type WSMessage struct {
-	Type    string      `json:"type"` // "message", "typing", "read", etc.
-	Payload interface{} `json:"payload"`
+	V       int         `json:"v"`
+	Type    string      `json:"type"`
+	ID      string      `json:"id,omitempty"`
+	Seq     int64       `json:"seq,omitempty"`
+	Payload interface{} `json:"payload,omitempty"`
+}
+
+// WSRequest is the envelope of every frame a client sends. The payload is
+// decoded into the struct of the type once the type is known.
+type WSRequest struct {
+	V       int             `json:"v" validate:"required"`
+	Type    string          `json:"type" validate:"required"`
+	ID      string          `json:"id,omitempty" validate:"max=64"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Client payloads
+type WSSendMessagePayload struct {
+	ChatID    int    `json:"chat_id" validate:"required"`
+	Content   string `json:"content" validate:"required"`
+	ReplyToID int    `json:"reply_to_id,omitempty" validate:"omitempty,min=1"`
+}
+
+type WSChatRoomPayload struct {
+	ChatID int `json:"chat_id" validate:"required"`
+}
+
+type WSReadPayload struct {
+	ChatID    int `json:"chat_id" validate:"required"`
+	MessageID int `json:"message_id" validate:"required"`
+}
+
+type WSPresenceSetPayload struct {
+	Status string `json:"status" validate:"required,oneof=online away"`
+}
+
+// Server payloads
+type WSAckPayload struct {
+	ChatID    int `json:"chat_id,omitempty"`
+	MessageID int `json:"message_id,omitempty"` // the persisted message of a "message" request, or the message a "read" request read up to
+}
+
+type WSErrorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type WSChatMessage struct {
@@ -103,6 +451,75 @@ type WSChatMessage struct {
 	Username  string    `json:"username"`
 	ChatID    int       `json:"chat_id"`
 	Timestamp time.Time `json:"timestamp"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ReplyToID int       `json:"reply_to_id,omitempty"`
+}
+
+// WSThreadPayload tells that a reply to a message was sent or deleted.
+type WSThreadPayload struct {
+	ChatID      int        `json:"chat_id"`
+	MessageID   int        `json:"message_id"` // the message replied to
+	ReplyCount  int        `json:"reply_count"`
+	LastReplyAt *time.Time `json:"last_reply_at"`
+}
+
+// WSReadReceiptPayload tells that a member read a chat up to a message.
+type WSReadReceiptPayload struct {
+	ChatID    int       `json:"chat_id"`
+	UserID    int       `json:"user_id"`
+	MessageID int       `json:"message_id"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
+// WSReactionPayload tells that a member added or removed a reaction.
+type WSReactionPayload struct {
+	ChatID    int    `json:"chat_id"`
+	MessageID int    `json:"message_id"`
+	UserID    int    `json:"user_id"`
+	Emoji     string `json:"emoji"`
+}
+
+// WSMessageDeliveredPayload tells the sender that messages reached
+// recipients. Deliveries recorded together arrive in one frame.
+type WSMessageDeliveredPayload struct {
+	Deliveries []WSDelivery `json:"deliveries"`
+}
+
+// WSDelivery is a message that reached one of its recipients.
+type WSDelivery struct {
+	MessageID   int       `json:"message_id"`
+	ChatID      int       `json:"chat_id"`
+	UserID      int       `json:"user_id"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+type WSMessageDeletedPayload struct {
+	MessageID int `json:"message_id"`
+	ChatID    int `json:"chat_id"`
+}
+
+// WSMemberPayload lists the users that were added to or removed from a chat.
+type WSMemberPayload struct {
+	ChatID  int   `json:"chat_id"`
+	UserIDs []int `json:"user_ids"`
+}
+
+// WSResyncPayload tells a reconnecting client that the events it missed can
+// no longer be replayed. It should reload its chats and continue from LatestSeq.
+type WSResyncPayload struct {
+	LatestSeq int64 `json:"latest_seq"`
+}
+
+// WSTypingPayload is a user typing, or no longer typing, in a chat.
+type WSTypingPayload struct {
+	ChatID   int    `json:"chat_id"`
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+}
+
+type WSSystemPayload struct {
+	ChatID  int    `json:"chat_id"`
+	Message string `json:"message"`
 }
 
 type ErrorResponse struct {
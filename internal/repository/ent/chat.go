@@ -41,9 +41,11 @@ type ChatEdges struct {
 	Messages []*Message `json:"messages,omitempty"`
 	// Members holds the value of the members edge.
 	Members []*ChatMember `json:"members,omitempty"`
+	// Emoji holds the value of the emoji edge.
+	Emoji []*ChatEmoji `json:"emoji,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [3]bool
+	loadedTypes [4]bool
 }
 
 // CreatorOrErr returns the Creator value or an error if the edge
@@ -75,6 +77,15 @@ func (e ChatEdges) MembersOrErr() ([]*ChatMember, error) {
 	return nil, &NotLoadedError{edge: "members"}
 }
 
+// EmojiOrErr returns the Emoji value or an error if the edge
+// was not loaded in eager-loading.
+func (e ChatEdges) EmojiOrErr() ([]*ChatEmoji, error) {
+	if e.loadedTypes[3] {
+		return e.Emoji, nil
+	}
+	return nil, &NotLoadedError{edge: "emoji"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Chat) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -170,6 +181,11 @@ func (_m *Chat) QueryMembers() *ChatMemberQuery {
 	return NewChatClient(_m.config).QueryMembers(_m)
 }
 
+// QueryEmoji queries the "emoji" edge of the Chat entity.
+func (_m *Chat) QueryEmoji() *ChatEmojiQuery {
+	return NewChatClient(_m.config).QueryEmoji(_m)
+}
+
 // Update returns a builder for updating this Chat.
 // Note that you need to call Chat.Unwrap() before calling this method if this Chat
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -28,6 +28,8 @@ const (
 	EdgeMessages = "messages"
 	// EdgeMembers holds the string denoting the members edge name in mutations.
 	EdgeMembers = "members"
+	// EdgeEmoji holds the string denoting the emoji edge name in mutations.
+	EdgeEmoji = "emoji"
 	// Table holds the table name of the chat in the database.
 	Table = "chats"
 	// CreatorTable is the table that holds the creator relation/edge.
@@ -51,6 +53,13 @@ const (
 	MembersInverseTable = "chat_members"
 	// MembersColumn is the table column denoting the members relation/edge.
 	MembersColumn = "chat_members"
+	// EmojiTable is the table that holds the emoji relation/edge.
+	EmojiTable = "chat_emojis"
+	// EmojiInverseTable is the table name for the ChatEmoji entity.
+	// It exists in this package in order to avoid circular dependency with the "chatemoji" package.
+	EmojiInverseTable = "chat_emojis"
+	// EmojiColumn is the table column denoting the emoji relation/edge.
+	EmojiColumn = "chat_emoji"
 )
 
 // Columns holds all SQL columns for chat fields.
@@ -158,6 +167,20 @@ func ByMembers(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newMembersStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByEmojiCount orders the results by emoji count.
+func ByEmojiCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newEmojiStep(), opts...)
+	}
+}
+
+// ByEmoji orders the results by emoji terms.
+func ByEmoji(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newEmojiStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newCreatorStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -179,3 +202,10 @@ func newMembersStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, MembersTable, MembersColumn),
 	)
 }
+func newEmojiStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(EmojiInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, EmojiTable, EmojiColumn),
+	)
+}
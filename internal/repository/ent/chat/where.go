@@ -299,6 +299,29 @@ func HasMembersWith(preds ...predicate.ChatMember) predicate.Chat {
 	})
 }
 
+// HasEmoji applies the HasEdge predicate on the "emoji" edge.
+func HasEmoji() predicate.Chat {
+	return predicate.Chat(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, EmojiTable, EmojiColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasEmojiWith applies the HasEdge predicate on the "emoji" edge with a given conditions (other predicates).
+func HasEmojiWith(preds ...predicate.ChatEmoji) predicate.Chat {
+	return predicate.Chat(func(s *sql.Selector) {
+		step := newEmojiStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Chat) predicate.Chat {
 	return predicate.Chat(sql.AndPredicates(predicates...))
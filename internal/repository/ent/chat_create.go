@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
@@ -122,6 +123,21 @@ func (_c *ChatCreate) AddMembers(v ...*ChatMember) *ChatCreate {
 	return _c.AddMemberIDs(ids...)
 }
 
+// AddEmojiIDs adds the "emoji" edge to the ChatEmoji entity by IDs.
+func (_c *ChatCreate) AddEmojiIDs(ids ...int) *ChatCreate {
+	_c.mutation.AddEmojiIDs(ids...)
+	return _c
+}
+
+// AddEmoji adds the "emoji" edges to the ChatEmoji entity.
+func (_c *ChatCreate) AddEmoji(v ...*ChatEmoji) *ChatCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddEmojiIDs(ids...)
+}
+
 // Mutation returns the ChatMutation object of the builder.
 func (_c *ChatCreate) Mutation() *ChatMutation {
 	return _c.mutation
@@ -282,6 +298,22 @@ func (_c *ChatCreate) createSpec() (*Chat, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.EmojiIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -13,6 +13,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/predicate"
@@ -29,7 +30,9 @@ type ChatQuery struct {
 	withCreator  *UserQuery
 	withMessages *MessageQuery
 	withMembers  *ChatMemberQuery
+	withEmoji    *ChatEmojiQuery
 	withFKs      bool
+	modifiers    []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -132,6 +135,28 @@ func (_q *ChatQuery) QueryMembers() *ChatMemberQuery {
 	return query
 }
 
+// QueryEmoji chains the current query on the "emoji" edge.
+func (_q *ChatQuery) QueryEmoji() *ChatEmojiQuery {
+	query := (&ChatEmojiClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(chat.Table, chat.FieldID, selector),
+			sqlgraph.To(chatemoji.Table, chatemoji.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, chat.EmojiTable, chat.EmojiColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Chat entity from the query.
 // Returns a *NotFoundError when no Chat was found.
 func (_q *ChatQuery) First(ctx context.Context) (*Chat, error) {
@@ -327,9 +352,11 @@ func (_q *ChatQuery) Clone() *ChatQuery {
 		withCreator:  _q.withCreator.Clone(),
 		withMessages: _q.withMessages.Clone(),
 		withMembers:  _q.withMembers.Clone(),
+		withEmoji:    _q.withEmoji.Clone(),
 		// clone intermediate query.
-		sql:  _q.sql.Clone(),
-		path: _q.path,
+		sql:       _q.sql.Clone(),
+		path:      _q.path,
+		modifiers: append([]func(*sql.Selector){}, _q.modifiers...),
 	}
 }
 
@@ -366,6 +393,17 @@ func (_q *ChatQuery) WithMembers(opts ...func(*ChatMemberQuery)) *ChatQuery {
 	return _q
 }
 
+// WithEmoji tells the query-builder to eager-load the nodes that are connected to
+// the "emoji" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *ChatQuery) WithEmoji(opts ...func(*ChatEmojiQuery)) *ChatQuery {
+	query := (&ChatEmojiClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withEmoji = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -445,10 +483,11 @@ func (_q *ChatQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Chat, e
 		nodes       = []*Chat{}
 		withFKs     = _q.withFKs
 		_spec       = _q.querySpec()
-		loadedTypes = [3]bool{
+		loadedTypes = [4]bool{
 			_q.withCreator != nil,
 			_q.withMessages != nil,
 			_q.withMembers != nil,
+			_q.withEmoji != nil,
 		}
 	)
 	if _q.withCreator != nil {
@@ -466,6 +505,9 @@ func (_q *ChatQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Chat, e
 		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	for i := range hooks {
 		hooks[i](ctx, _spec)
 	}
@@ -495,6 +537,13 @@ func (_q *ChatQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Chat, e
 			return nil, err
 		}
 	}
+	if query := _q.withEmoji; query != nil {
+		if err := _q.loadEmoji(ctx, query, nodes,
+			func(n *Chat) { n.Edges.Emoji = []*ChatEmoji{} },
+			func(n *Chat, e *ChatEmoji) { n.Edges.Emoji = append(n.Edges.Emoji, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -592,9 +641,43 @@ func (_q *ChatQuery) loadMembers(ctx context.Context, query *ChatMemberQuery, no
 	}
 	return nil
 }
+func (_q *ChatQuery) loadEmoji(ctx context.Context, query *ChatEmojiQuery, nodes []*Chat, init func(*Chat), assign func(*Chat, *ChatEmoji)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Chat)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.ChatEmoji(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(chat.EmojiColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.chat_emoji
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "chat_emoji" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "chat_emoji" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (_q *ChatQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	_spec.Node.Columns = _q.ctx.Fields
 	if len(_q.ctx.Fields) > 0 {
 		_spec.Unique = _q.ctx.Unique != nil && *_q.ctx.Unique
@@ -657,6 +740,9 @@ func (_q *ChatQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	if _q.ctx.Unique != nil && *_q.ctx.Unique {
 		selector.Distinct()
 	}
+	for _, m := range _q.modifiers {
+		m(selector)
+	}
 	for _, p := range _q.predicates {
 		p(selector)
 	}
@@ -674,6 +760,12 @@ func (_q *ChatQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// Modify adds a query modifier for attaching custom logic to queries.
+func (_q *ChatQuery) Modify(modifiers ...func(s *sql.Selector)) *ChatSelect {
+	_q.modifiers = append(_q.modifiers, modifiers...)
+	return _q.Select()
+}
+
 // ChatGroupBy is the group-by builder for Chat entities.
 type ChatGroupBy struct {
 	selector
@@ -763,3 +855,9 @@ func (_s *ChatSelect) sqlScan(ctx context.Context, root *ChatQuery, v any) error
 	defer rows.Close()
 	return sql.ScanSlice(rows, v)
 }
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (_s *ChatSelect) Modify(modifiers ...func(s *sql.Selector)) *ChatSelect {
+	_s.modifiers = append(_s.modifiers, modifiers...)
+	return _s
+}
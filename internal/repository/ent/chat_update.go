@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/predicate"
@@ -21,8 +22,9 @@ import (
 // ChatUpdate is the builder for updating Chat entities.
 type ChatUpdate struct {
 	config
-	hooks    []Hook
-	mutation *ChatMutation
+	hooks     []Hook
+	mutation  *ChatMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // Where appends a list predicates to the ChatUpdate builder.
@@ -114,6 +116,21 @@ func (_u *ChatUpdate) AddMembers(v ...*ChatMember) *ChatUpdate {
 	return _u.AddMemberIDs(ids...)
 }
 
+// AddEmojiIDs adds the "emoji" edge to the ChatEmoji entity by IDs.
+func (_u *ChatUpdate) AddEmojiIDs(ids ...int) *ChatUpdate {
+	_u.mutation.AddEmojiIDs(ids...)
+	return _u
+}
+
+// AddEmoji adds the "emoji" edges to the ChatEmoji entity.
+func (_u *ChatUpdate) AddEmoji(v ...*ChatEmoji) *ChatUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddEmojiIDs(ids...)
+}
+
 // Mutation returns the ChatMutation object of the builder.
 func (_u *ChatUpdate) Mutation() *ChatMutation {
 	return _u.mutation
@@ -167,6 +184,27 @@ func (_u *ChatUpdate) RemoveMembers(v ...*ChatMember) *ChatUpdate {
 	return _u.RemoveMemberIDs(ids...)
 }
 
+// ClearEmoji clears all "emoji" edges to the ChatEmoji entity.
+func (_u *ChatUpdate) ClearEmoji() *ChatUpdate {
+	_u.mutation.ClearEmoji()
+	return _u
+}
+
+// RemoveEmojiIDs removes the "emoji" edge to ChatEmoji entities by IDs.
+func (_u *ChatUpdate) RemoveEmojiIDs(ids ...int) *ChatUpdate {
+	_u.mutation.RemoveEmojiIDs(ids...)
+	return _u
+}
+
+// RemoveEmoji removes "emoji" edges to ChatEmoji entities.
+func (_u *ChatUpdate) RemoveEmoji(v ...*ChatEmoji) *ChatUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveEmojiIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *ChatUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -213,6 +251,12 @@ func (_u *ChatUpdate) check() error {
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *ChatUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *ChatUpdate {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
 func (_u *ChatUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if err := _u.check(); err != nil {
 		return _node, err
@@ -353,6 +397,52 @@ func (_u *ChatUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.EmojiCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedEmojiIDs(); len(nodes) > 0 && !_u.mutation.EmojiCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.EmojiIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(_u.modifiers...)
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{chat.Label}
@@ -368,9 +458,10 @@ func (_u *ChatUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 // ChatUpdateOne is the builder for updating a single Chat entity.
 type ChatUpdateOne struct {
 	config
-	fields   []string
-	hooks    []Hook
-	mutation *ChatMutation
+	fields    []string
+	hooks     []Hook
+	mutation  *ChatMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // SetName sets the "name" field.
@@ -456,6 +547,21 @@ func (_u *ChatUpdateOne) AddMembers(v ...*ChatMember) *ChatUpdateOne {
 	return _u.AddMemberIDs(ids...)
 }
 
+// AddEmojiIDs adds the "emoji" edge to the ChatEmoji entity by IDs.
+func (_u *ChatUpdateOne) AddEmojiIDs(ids ...int) *ChatUpdateOne {
+	_u.mutation.AddEmojiIDs(ids...)
+	return _u
+}
+
+// AddEmoji adds the "emoji" edges to the ChatEmoji entity.
+func (_u *ChatUpdateOne) AddEmoji(v ...*ChatEmoji) *ChatUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddEmojiIDs(ids...)
+}
+
 // Mutation returns the ChatMutation object of the builder.
 func (_u *ChatUpdateOne) Mutation() *ChatMutation {
 	return _u.mutation
@@ -509,6 +615,27 @@ func (_u *ChatUpdateOne) RemoveMembers(v ...*ChatMember) *ChatUpdateOne {
 	return _u.RemoveMemberIDs(ids...)
 }
 
+// ClearEmoji clears all "emoji" edges to the ChatEmoji entity.
+func (_u *ChatUpdateOne) ClearEmoji() *ChatUpdateOne {
+	_u.mutation.ClearEmoji()
+	return _u
+}
+
+// RemoveEmojiIDs removes the "emoji" edge to ChatEmoji entities by IDs.
+func (_u *ChatUpdateOne) RemoveEmojiIDs(ids ...int) *ChatUpdateOne {
+	_u.mutation.RemoveEmojiIDs(ids...)
+	return _u
+}
+
+// RemoveEmoji removes "emoji" edges to ChatEmoji entities.
+func (_u *ChatUpdateOne) RemoveEmoji(v ...*ChatEmoji) *ChatUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveEmojiIDs(ids...)
+}
+
 // Where appends a list predicates to the ChatUpdate builder.
 func (_u *ChatUpdateOne) Where(ps ...predicate.Chat) *ChatUpdateOne {
 	_u.mutation.Where(ps...)
@@ -568,6 +695,12 @@ func (_u *ChatUpdateOne) check() error {
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *ChatUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *ChatUpdateOne {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
 func (_u *ChatUpdateOne) sqlSave(ctx context.Context) (_node *Chat, err error) {
 	if err := _u.check(); err != nil {
 		return _node, err
@@ -725,6 +858,52 @@ func (_u *ChatUpdateOne) sqlSave(ctx context.Context) (_node *Chat, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.EmojiCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedEmojiIDs(); len(nodes) > 0 && !_u.mutation.EmojiCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.EmojiIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   chat.EmojiTable,
+			Columns: []string{chat.EmojiColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(_u.modifiers...)
 	_node = &Chat{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
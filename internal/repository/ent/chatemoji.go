@@ -0,0 +1,167 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
+)
+
+// ChatEmoji is the model entity for the ChatEmoji schema.
+type ChatEmoji struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// ImageURL holds the value of the "image_url" field.
+	ImageURL string `json:"image_url,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ChatEmojiQuery when eager-loading is set.
+	Edges        ChatEmojiEdges `json:"edges"`
+	chat_emoji   *int
+	selectValues sql.SelectValues
+}
+
+// ChatEmojiEdges holds the relations/edges for other nodes in the graph.
+type ChatEmojiEdges struct {
+	// Chat holds the value of the chat edge.
+	Chat *Chat `json:"chat,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ChatOrErr returns the Chat value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ChatEmojiEdges) ChatOrErr() (*Chat, error) {
+	if e.Chat != nil {
+		return e.Chat, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: chat.Label}
+	}
+	return nil, &NotLoadedError{edge: "chat"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ChatEmoji) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case chatemoji.FieldID:
+			values[i] = new(sql.NullInt64)
+		case chatemoji.FieldName, chatemoji.FieldImageURL:
+			values[i] = new(sql.NullString)
+		case chatemoji.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case chatemoji.ForeignKeys[0]: // chat_emoji
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ChatEmoji fields.
+func (_m *ChatEmoji) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case chatemoji.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case chatemoji.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case chatemoji.FieldImageURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field image_url", values[i])
+			} else if value.Valid {
+				_m.ImageURL = value.String
+			}
+		case chatemoji.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case chatemoji.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field chat_emoji", value)
+			} else if value.Valid {
+				_m.chat_emoji = new(int)
+				*_m.chat_emoji = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ChatEmoji.
+// This includes values selected through modifiers, order, etc.
+func (_m *ChatEmoji) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryChat queries the "chat" edge of the ChatEmoji entity.
+func (_m *ChatEmoji) QueryChat() *ChatQuery {
+	return NewChatEmojiClient(_m.config).QueryChat(_m)
+}
+
+// Update returns a builder for updating this ChatEmoji.
+// Note that you need to call ChatEmoji.Unwrap() before calling this method if this ChatEmoji
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *ChatEmoji) Update() *ChatEmojiUpdateOne {
+	return NewChatEmojiClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the ChatEmoji entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *ChatEmoji) Unwrap() *ChatEmoji {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ChatEmoji is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *ChatEmoji) String() string {
+	var builder strings.Builder
+	builder.WriteString("ChatEmoji(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("image_url=")
+	builder.WriteString(_m.ImageURL)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ChatEmojis is a parsable slice of ChatEmoji.
+type ChatEmojis []*ChatEmoji
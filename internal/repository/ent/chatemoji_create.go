@@ -0,0 +1,559 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
+)
+
+// ChatEmojiCreate is the builder for creating a ChatEmoji entity.
+type ChatEmojiCreate struct {
+	config
+	mutation *ChatEmojiMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetName sets the "name" field.
+func (_c *ChatEmojiCreate) SetName(v string) *ChatEmojiCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetImageURL sets the "image_url" field.
+func (_c *ChatEmojiCreate) SetImageURL(v string) *ChatEmojiCreate {
+	_c.mutation.SetImageURL(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *ChatEmojiCreate) SetCreatedAt(v time.Time) *ChatEmojiCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *ChatEmojiCreate) SetNillableCreatedAt(v *time.Time) *ChatEmojiCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetChatID sets the "chat" edge to the Chat entity by ID.
+func (_c *ChatEmojiCreate) SetChatID(id int) *ChatEmojiCreate {
+	_c.mutation.SetChatID(id)
+	return _c
+}
+
+// SetChat sets the "chat" edge to the Chat entity.
+func (_c *ChatEmojiCreate) SetChat(v *Chat) *ChatEmojiCreate {
+	return _c.SetChatID(v.ID)
+}
+
+// Mutation returns the ChatEmojiMutation object of the builder.
+func (_c *ChatEmojiCreate) Mutation() *ChatEmojiMutation {
+	return _c.mutation
+}
+
+// Save creates the ChatEmoji in the database.
+func (_c *ChatEmojiCreate) Save(ctx context.Context) (*ChatEmoji, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *ChatEmojiCreate) SaveX(ctx context.Context) *ChatEmoji {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ChatEmojiCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ChatEmojiCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *ChatEmojiCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := chatemoji.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *ChatEmojiCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "ChatEmoji.name"`)}
+	}
+	if v, ok := _c.mutation.Name(); ok {
+		if err := chatemoji.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "ChatEmoji.name": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ImageURL(); !ok {
+		return &ValidationError{Name: "image_url", err: errors.New(`ent: missing required field "ChatEmoji.image_url"`)}
+	}
+	if v, ok := _c.mutation.ImageURL(); ok {
+		if err := chatemoji.ImageURLValidator(v); err != nil {
+			return &ValidationError{Name: "image_url", err: fmt.Errorf(`ent: validator failed for field "ChatEmoji.image_url": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ChatEmoji.created_at"`)}
+	}
+	if len(_c.mutation.ChatIDs()) == 0 {
+		return &ValidationError{Name: "chat", err: errors.New(`ent: missing required edge "ChatEmoji.chat"`)}
+	}
+	return nil
+}
+
+func (_c *ChatEmojiCreate) sqlSave(ctx context.Context) (*ChatEmoji, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *ChatEmojiCreate) createSpec() (*ChatEmoji, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ChatEmoji{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(chatemoji.Table, sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(chatemoji.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.ImageURL(); ok {
+		_spec.SetField(chatemoji.FieldImageURL, field.TypeString, value)
+		_node.ImageURL = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(chatemoji.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.ChatIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   chatemoji.ChatTable,
+			Columns: []string{chatemoji.ChatColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(chat.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.chat_emoji = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ChatEmoji.Create().
+//		SetName(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ChatEmojiUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *ChatEmojiCreate) OnConflict(opts ...sql.ConflictOption) *ChatEmojiUpsertOne {
+	_c.conflict = opts
+	return &ChatEmojiUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ChatEmoji.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *ChatEmojiCreate) OnConflictColumns(columns ...string) *ChatEmojiUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &ChatEmojiUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// ChatEmojiUpsertOne is the builder for "upsert"-ing
+	//  one ChatEmoji node.
+	ChatEmojiUpsertOne struct {
+		create *ChatEmojiCreate
+	}
+
+	// ChatEmojiUpsert is the "OnConflict" setter.
+	ChatEmojiUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetImageURL sets the "image_url" field.
+func (u *ChatEmojiUpsert) SetImageURL(v string) *ChatEmojiUpsert {
+	u.Set(chatemoji.FieldImageURL, v)
+	return u
+}
+
+// UpdateImageURL sets the "image_url" field to the value that was provided on create.
+func (u *ChatEmojiUpsert) UpdateImageURL() *ChatEmojiUpsert {
+	u.SetExcluded(chatemoji.FieldImageURL)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.ChatEmoji.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *ChatEmojiUpsertOne) UpdateNewValues() *ChatEmojiUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.Name(); exists {
+			s.SetIgnore(chatemoji.FieldName)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(chatemoji.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ChatEmoji.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *ChatEmojiUpsertOne) Ignore() *ChatEmojiUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ChatEmojiUpsertOne) DoNothing() *ChatEmojiUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ChatEmojiCreate.OnConflict
+// documentation for more info.
+func (u *ChatEmojiUpsertOne) Update(set func(*ChatEmojiUpsert)) *ChatEmojiUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ChatEmojiUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetImageURL sets the "image_url" field.
+func (u *ChatEmojiUpsertOne) SetImageURL(v string) *ChatEmojiUpsertOne {
+	return u.Update(func(s *ChatEmojiUpsert) {
+		s.SetImageURL(v)
+	})
+}
+
+// UpdateImageURL sets the "image_url" field to the value that was provided on create.
+func (u *ChatEmojiUpsertOne) UpdateImageURL() *ChatEmojiUpsertOne {
+	return u.Update(func(s *ChatEmojiUpsert) {
+		s.UpdateImageURL()
+	})
+}
+
+// Exec executes the query.
+func (u *ChatEmojiUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ChatEmojiCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ChatEmojiUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *ChatEmojiUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *ChatEmojiUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ChatEmojiCreateBulk is the builder for creating many ChatEmoji entities in bulk.
+type ChatEmojiCreateBulk struct {
+	config
+	err      error
+	builders []*ChatEmojiCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the ChatEmoji entities in the database.
+func (_c *ChatEmojiCreateBulk) Save(ctx context.Context) ([]*ChatEmoji, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*ChatEmoji, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ChatEmojiMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *ChatEmojiCreateBulk) SaveX(ctx context.Context) []*ChatEmoji {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ChatEmojiCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ChatEmojiCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ChatEmoji.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ChatEmojiUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *ChatEmojiCreateBulk) OnConflict(opts ...sql.ConflictOption) *ChatEmojiUpsertBulk {
+	_c.conflict = opts
+	return &ChatEmojiUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ChatEmoji.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *ChatEmojiCreateBulk) OnConflictColumns(columns ...string) *ChatEmojiUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &ChatEmojiUpsertBulk{
+		create: _c,
+	}
+}
+
+// ChatEmojiUpsertBulk is the builder for "upsert"-ing
+// a bulk of ChatEmoji nodes.
+type ChatEmojiUpsertBulk struct {
+	create *ChatEmojiCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.ChatEmoji.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *ChatEmojiUpsertBulk) UpdateNewValues() *ChatEmojiUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.Name(); exists {
+				s.SetIgnore(chatemoji.FieldName)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(chatemoji.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ChatEmoji.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *ChatEmojiUpsertBulk) Ignore() *ChatEmojiUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ChatEmojiUpsertBulk) DoNothing() *ChatEmojiUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ChatEmojiCreateBulk.OnConflict
+// documentation for more info.
+func (u *ChatEmojiUpsertBulk) Update(set func(*ChatEmojiUpsert)) *ChatEmojiUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ChatEmojiUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetImageURL sets the "image_url" field.
+func (u *ChatEmojiUpsertBulk) SetImageURL(v string) *ChatEmojiUpsertBulk {
+	return u.Update(func(s *ChatEmojiUpsert) {
+		s.SetImageURL(v)
+	})
+}
+
+// UpdateImageURL sets the "image_url" field to the value that was provided on create.
+func (u *ChatEmojiUpsertBulk) UpdateImageURL() *ChatEmojiUpsertBulk {
+	return u.Update(func(s *ChatEmojiUpsert) {
+		s.UpdateImageURL()
+	})
+}
+
+// Exec executes the query.
+func (u *ChatEmojiUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the ChatEmojiCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ChatEmojiCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ChatEmojiUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,257 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/predicate"
+)
+
+// ChatEmojiUpdate is the builder for updating ChatEmoji entities.
+type ChatEmojiUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *ChatEmojiMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the ChatEmojiUpdate builder.
+func (_u *ChatEmojiUpdate) Where(ps ...predicate.ChatEmoji) *ChatEmojiUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetImageURL sets the "image_url" field.
+func (_u *ChatEmojiUpdate) SetImageURL(v string) *ChatEmojiUpdate {
+	_u.mutation.SetImageURL(v)
+	return _u
+}
+
+// SetNillableImageURL sets the "image_url" field if the given value is not nil.
+func (_u *ChatEmojiUpdate) SetNillableImageURL(v *string) *ChatEmojiUpdate {
+	if v != nil {
+		_u.SetImageURL(*v)
+	}
+	return _u
+}
+
+// Mutation returns the ChatEmojiMutation object of the builder.
+func (_u *ChatEmojiUpdate) Mutation() *ChatEmojiMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *ChatEmojiUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ChatEmojiUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *ChatEmojiUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ChatEmojiUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ChatEmojiUpdate) check() error {
+	if v, ok := _u.mutation.ImageURL(); ok {
+		if err := chatemoji.ImageURLValidator(v); err != nil {
+			return &ValidationError{Name: "image_url", err: fmt.Errorf(`ent: validator failed for field "ChatEmoji.image_url": %w`, err)}
+		}
+	}
+	if _u.mutation.ChatCleared() && len(_u.mutation.ChatIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ChatEmoji.chat"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *ChatEmojiUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *ChatEmojiUpdate {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
+func (_u *ChatEmojiUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(chatemoji.Table, chatemoji.Columns, sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ImageURL(); ok {
+		_spec.SetField(chatemoji.FieldImageURL, field.TypeString, value)
+	}
+	_spec.AddModifiers(_u.modifiers...)
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{chatemoji.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// ChatEmojiUpdateOne is the builder for updating a single ChatEmoji entity.
+type ChatEmojiUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *ChatEmojiMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetImageURL sets the "image_url" field.
+func (_u *ChatEmojiUpdateOne) SetImageURL(v string) *ChatEmojiUpdateOne {
+	_u.mutation.SetImageURL(v)
+	return _u
+}
+
+// SetNillableImageURL sets the "image_url" field if the given value is not nil.
+func (_u *ChatEmojiUpdateOne) SetNillableImageURL(v *string) *ChatEmojiUpdateOne {
+	if v != nil {
+		_u.SetImageURL(*v)
+	}
+	return _u
+}
+
+// Mutation returns the ChatEmojiMutation object of the builder.
+func (_u *ChatEmojiUpdateOne) Mutation() *ChatEmojiMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the ChatEmojiUpdate builder.
+func (_u *ChatEmojiUpdateOne) Where(ps ...predicate.ChatEmoji) *ChatEmojiUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *ChatEmojiUpdateOne) Select(field string, fields ...string) *ChatEmojiUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated ChatEmoji entity.
+func (_u *ChatEmojiUpdateOne) Save(ctx context.Context) (*ChatEmoji, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ChatEmojiUpdateOne) SaveX(ctx context.Context) *ChatEmoji {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *ChatEmojiUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ChatEmojiUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ChatEmojiUpdateOne) check() error {
+	if v, ok := _u.mutation.ImageURL(); ok {
+		if err := chatemoji.ImageURLValidator(v); err != nil {
+			return &ValidationError{Name: "image_url", err: fmt.Errorf(`ent: validator failed for field "ChatEmoji.image_url": %w`, err)}
+		}
+	}
+	if _u.mutation.ChatCleared() && len(_u.mutation.ChatIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ChatEmoji.chat"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *ChatEmojiUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *ChatEmojiUpdateOne {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
+func (_u *ChatEmojiUpdateOne) sqlSave(ctx context.Context) (_node *ChatEmoji, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(chatemoji.Table, chatemoji.Columns, sqlgraph.NewFieldSpec(chatemoji.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ChatEmoji.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, chatemoji.FieldID)
+		for _, f := range fields {
+			if !chatemoji.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != chatemoji.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ImageURL(); ok {
+		_spec.SetField(chatemoji.FieldImageURL, field.TypeString, value)
+	}
+	_spec.AddModifiers(_u.modifiers...)
+	_node = &ChatEmoji{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{chatemoji.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -23,6 +23,10 @@ type ChatMember struct {
 	JoinedAt time.Time `json:"joined_at,omitempty"`
 	// IsAdmin holds the value of the "is_admin" field.
 	IsAdmin bool `json:"is_admin,omitempty"`
+	// LastReadMessageID holds the value of the "last_read_message_id" field.
+	LastReadMessageID *int `json:"last_read_message_id,omitempty"`
+	// LastReadAt holds the value of the "last_read_at" field.
+	LastReadAt *time.Time `json:"last_read_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ChatMemberQuery when eager-loading is set.
 	Edges             ChatMemberEdges `json:"edges"`
@@ -71,9 +75,9 @@ func (*ChatMember) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case chatmember.FieldIsAdmin:
 			values[i] = new(sql.NullBool)
-		case chatmember.FieldID:
+		case chatmember.FieldID, chatmember.FieldLastReadMessageID:
 			values[i] = new(sql.NullInt64)
-		case chatmember.FieldJoinedAt:
+		case chatmember.FieldJoinedAt, chatmember.FieldLastReadAt:
 			values[i] = new(sql.NullTime)
 		case chatmember.ForeignKeys[0]: // chat_members
 			values[i] = new(sql.NullInt64)
@@ -112,6 +116,20 @@ func (_m *ChatMember) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.IsAdmin = value.Bool
 			}
+		case chatmember.FieldLastReadMessageID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field last_read_message_id", values[i])
+			} else if value.Valid {
+				_m.LastReadMessageID = new(int)
+				*_m.LastReadMessageID = int(value.Int64)
+			}
+		case chatmember.FieldLastReadAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_read_at", values[i])
+			} else if value.Valid {
+				_m.LastReadAt = new(time.Time)
+				*_m.LastReadAt = value.Time
+			}
 		case chatmember.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field chat_members", value)
@@ -177,6 +195,16 @@ func (_m *ChatMember) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("is_admin=")
 	builder.WriteString(fmt.Sprintf("%v", _m.IsAdmin))
+	builder.WriteString(", ")
+	if v := _m.LastReadMessageID; v != nil {
+		builder.WriteString("last_read_message_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.LastReadAt; v != nil {
+		builder.WriteString("last_read_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -18,6 +18,10 @@ const (
 	FieldJoinedAt = "joined_at"
 	// FieldIsAdmin holds the string denoting the is_admin field in the database.
 	FieldIsAdmin = "is_admin"
+	// FieldLastReadMessageID holds the string denoting the last_read_message_id field in the database.
+	FieldLastReadMessageID = "last_read_message_id"
+	// FieldLastReadAt holds the string denoting the last_read_at field in the database.
+	FieldLastReadAt = "last_read_at"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// EdgeChat holds the string denoting the chat edge name in mutations.
@@ -45,6 +49,8 @@ var Columns = []string{
 	FieldID,
 	FieldJoinedAt,
 	FieldIsAdmin,
+	FieldLastReadMessageID,
+	FieldLastReadAt,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "chat_members"
@@ -94,6 +100,16 @@ func ByIsAdmin(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldIsAdmin, opts...).ToFunc()
 }
 
+// ByLastReadMessageID orders the results by the last_read_message_id field.
+func ByLastReadMessageID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastReadMessageID, opts...).ToFunc()
+}
+
+// ByLastReadAt orders the results by the last_read_at field.
+func ByLastReadAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastReadAt, opts...).ToFunc()
+}
+
 // ByUserField orders the results by user field.
 func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -65,6 +65,16 @@ func IsAdmin(v bool) predicate.ChatMember {
 	return predicate.ChatMember(sql.FieldEQ(FieldIsAdmin, v))
 }
 
+// LastReadMessageID applies equality check predicate on the "last_read_message_id" field. It's identical to LastReadMessageIDEQ.
+func LastReadMessageID(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldEQ(FieldLastReadMessageID, v))
+}
+
+// LastReadAt applies equality check predicate on the "last_read_at" field. It's identical to LastReadAtEQ.
+func LastReadAt(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldEQ(FieldLastReadAt, v))
+}
+
 // JoinedAtEQ applies the EQ predicate on the "joined_at" field.
 func JoinedAtEQ(v time.Time) predicate.ChatMember {
 	return predicate.ChatMember(sql.FieldEQ(FieldJoinedAt, v))
@@ -115,6 +125,106 @@ func IsAdminNEQ(v bool) predicate.ChatMember {
 	return predicate.ChatMember(sql.FieldNEQ(FieldIsAdmin, v))
 }
 
+// LastReadMessageIDEQ applies the EQ predicate on the "last_read_message_id" field.
+func LastReadMessageIDEQ(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldEQ(FieldLastReadMessageID, v))
+}
+
+// LastReadMessageIDNEQ applies the NEQ predicate on the "last_read_message_id" field.
+func LastReadMessageIDNEQ(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldNEQ(FieldLastReadMessageID, v))
+}
+
+// LastReadMessageIDIn applies the In predicate on the "last_read_message_id" field.
+func LastReadMessageIDIn(vs ...int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldIn(FieldLastReadMessageID, vs...))
+}
+
+// LastReadMessageIDNotIn applies the NotIn predicate on the "last_read_message_id" field.
+func LastReadMessageIDNotIn(vs ...int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldNotIn(FieldLastReadMessageID, vs...))
+}
+
+// LastReadMessageIDGT applies the GT predicate on the "last_read_message_id" field.
+func LastReadMessageIDGT(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldGT(FieldLastReadMessageID, v))
+}
+
+// LastReadMessageIDGTE applies the GTE predicate on the "last_read_message_id" field.
+func LastReadMessageIDGTE(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldGTE(FieldLastReadMessageID, v))
+}
+
+// LastReadMessageIDLT applies the LT predicate on the "last_read_message_id" field.
+func LastReadMessageIDLT(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldLT(FieldLastReadMessageID, v))
+}
+
+// LastReadMessageIDLTE applies the LTE predicate on the "last_read_message_id" field.
+func LastReadMessageIDLTE(v int) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldLTE(FieldLastReadMessageID, v))
+}
+
+// LastReadMessageIDIsNil applies the IsNil predicate on the "last_read_message_id" field.
+func LastReadMessageIDIsNil() predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldIsNull(FieldLastReadMessageID))
+}
+
+// LastReadMessageIDNotNil applies the NotNil predicate on the "last_read_message_id" field.
+func LastReadMessageIDNotNil() predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldNotNull(FieldLastReadMessageID))
+}
+
+// LastReadAtEQ applies the EQ predicate on the "last_read_at" field.
+func LastReadAtEQ(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldEQ(FieldLastReadAt, v))
+}
+
+// LastReadAtNEQ applies the NEQ predicate on the "last_read_at" field.
+func LastReadAtNEQ(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldNEQ(FieldLastReadAt, v))
+}
+
+// LastReadAtIn applies the In predicate on the "last_read_at" field.
+func LastReadAtIn(vs ...time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldIn(FieldLastReadAt, vs...))
+}
+
+// LastReadAtNotIn applies the NotIn predicate on the "last_read_at" field.
+func LastReadAtNotIn(vs ...time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldNotIn(FieldLastReadAt, vs...))
+}
+
+// LastReadAtGT applies the GT predicate on the "last_read_at" field.
+func LastReadAtGT(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldGT(FieldLastReadAt, v))
+}
+
+// LastReadAtGTE applies the GTE predicate on the "last_read_at" field.
+func LastReadAtGTE(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldGTE(FieldLastReadAt, v))
+}
+
+// LastReadAtLT applies the LT predicate on the "last_read_at" field.
+func LastReadAtLT(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldLT(FieldLastReadAt, v))
+}
+
+// LastReadAtLTE applies the LTE predicate on the "last_read_at" field.
+func LastReadAtLTE(v time.Time) predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldLTE(FieldLastReadAt, v))
+}
+
+// LastReadAtIsNil applies the IsNil predicate on the "last_read_at" field.
+func LastReadAtIsNil() predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldIsNull(FieldLastReadAt))
+}
+
+// LastReadAtNotNil applies the NotNil predicate on the "last_read_at" field.
+func LastReadAtNotNil() predicate.ChatMember {
+	return predicate.ChatMember(sql.FieldNotNull(FieldLastReadAt))
+}
+
 // HasUser applies the HasEdge predicate on the "user" edge.
 func HasUser() predicate.ChatMember {
 	return predicate.ChatMember(func(s *sql.Selector) {
@@ -52,6 +52,34 @@ func (_c *ChatMemberCreate) SetNillableIsAdmin(v *bool) *ChatMemberCreate {
 	return _c
 }
 
+// SetLastReadMessageID sets the "last_read_message_id" field.
+func (_c *ChatMemberCreate) SetLastReadMessageID(v int) *ChatMemberCreate {
+	_c.mutation.SetLastReadMessageID(v)
+	return _c
+}
+
+// SetNillableLastReadMessageID sets the "last_read_message_id" field if the given value is not nil.
+func (_c *ChatMemberCreate) SetNillableLastReadMessageID(v *int) *ChatMemberCreate {
+	if v != nil {
+		_c.SetLastReadMessageID(*v)
+	}
+	return _c
+}
+
+// SetLastReadAt sets the "last_read_at" field.
+func (_c *ChatMemberCreate) SetLastReadAt(v time.Time) *ChatMemberCreate {
+	_c.mutation.SetLastReadAt(v)
+	return _c
+}
+
+// SetNillableLastReadAt sets the "last_read_at" field if the given value is not nil.
+func (_c *ChatMemberCreate) SetNillableLastReadAt(v *time.Time) *ChatMemberCreate {
+	if v != nil {
+		_c.SetLastReadAt(*v)
+	}
+	return _c
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_c *ChatMemberCreate) SetUserID(id int) *ChatMemberCreate {
 	_c.mutation.SetUserID(id)
@@ -168,6 +196,14 @@ func (_c *ChatMemberCreate) createSpec() (*ChatMember, *sqlgraph.CreateSpec) {
 		_spec.SetField(chatmember.FieldIsAdmin, field.TypeBool, value)
 		_node.IsAdmin = value
 	}
+	if value, ok := _c.mutation.LastReadMessageID(); ok {
+		_spec.SetField(chatmember.FieldLastReadMessageID, field.TypeInt, value)
+		_node.LastReadMessageID = &value
+	}
+	if value, ok := _c.mutation.LastReadAt(); ok {
+		_spec.SetField(chatmember.FieldLastReadAt, field.TypeTime, value)
+		_node.LastReadAt = &value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -266,6 +302,48 @@ func (u *ChatMemberUpsert) UpdateIsAdmin() *ChatMemberUpsert {
 	return u
 }
 
+// SetLastReadMessageID sets the "last_read_message_id" field.
+func (u *ChatMemberUpsert) SetLastReadMessageID(v int) *ChatMemberUpsert {
+	u.Set(chatmember.FieldLastReadMessageID, v)
+	return u
+}
+
+// UpdateLastReadMessageID sets the "last_read_message_id" field to the value that was provided on create.
+func (u *ChatMemberUpsert) UpdateLastReadMessageID() *ChatMemberUpsert {
+	u.SetExcluded(chatmember.FieldLastReadMessageID)
+	return u
+}
+
+// AddLastReadMessageID adds v to the "last_read_message_id" field.
+func (u *ChatMemberUpsert) AddLastReadMessageID(v int) *ChatMemberUpsert {
+	u.Add(chatmember.FieldLastReadMessageID, v)
+	return u
+}
+
+// ClearLastReadMessageID clears the value of the "last_read_message_id" field.
+func (u *ChatMemberUpsert) ClearLastReadMessageID() *ChatMemberUpsert {
+	u.SetNull(chatmember.FieldLastReadMessageID)
+	return u
+}
+
+// SetLastReadAt sets the "last_read_at" field.
+func (u *ChatMemberUpsert) SetLastReadAt(v time.Time) *ChatMemberUpsert {
+	u.Set(chatmember.FieldLastReadAt, v)
+	return u
+}
+
+// UpdateLastReadAt sets the "last_read_at" field to the value that was provided on create.
+func (u *ChatMemberUpsert) UpdateLastReadAt() *ChatMemberUpsert {
+	u.SetExcluded(chatmember.FieldLastReadAt)
+	return u
+}
+
+// ClearLastReadAt clears the value of the "last_read_at" field.
+func (u *ChatMemberUpsert) ClearLastReadAt() *ChatMemberUpsert {
+	u.SetNull(chatmember.FieldLastReadAt)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -325,6 +403,55 @@ func (u *ChatMemberUpsertOne) UpdateIsAdmin() *ChatMemberUpsertOne {
 	})
 }
 
+// SetLastReadMessageID sets the "last_read_message_id" field.
+func (u *ChatMemberUpsertOne) SetLastReadMessageID(v int) *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.SetLastReadMessageID(v)
+	})
+}
+
+// AddLastReadMessageID adds v to the "last_read_message_id" field.
+func (u *ChatMemberUpsertOne) AddLastReadMessageID(v int) *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.AddLastReadMessageID(v)
+	})
+}
+
+// UpdateLastReadMessageID sets the "last_read_message_id" field to the value that was provided on create.
+func (u *ChatMemberUpsertOne) UpdateLastReadMessageID() *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.UpdateLastReadMessageID()
+	})
+}
+
+// ClearLastReadMessageID clears the value of the "last_read_message_id" field.
+func (u *ChatMemberUpsertOne) ClearLastReadMessageID() *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.ClearLastReadMessageID()
+	})
+}
+
+// SetLastReadAt sets the "last_read_at" field.
+func (u *ChatMemberUpsertOne) SetLastReadAt(v time.Time) *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.SetLastReadAt(v)
+	})
+}
+
+// UpdateLastReadAt sets the "last_read_at" field to the value that was provided on create.
+func (u *ChatMemberUpsertOne) UpdateLastReadAt() *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.UpdateLastReadAt()
+	})
+}
+
+// ClearLastReadAt clears the value of the "last_read_at" field.
+func (u *ChatMemberUpsertOne) ClearLastReadAt() *ChatMemberUpsertOne {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.ClearLastReadAt()
+	})
+}
+
 // Exec executes the query.
 func (u *ChatMemberUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -550,6 +677,55 @@ func (u *ChatMemberUpsertBulk) UpdateIsAdmin() *ChatMemberUpsertBulk {
 	})
 }
 
+// SetLastReadMessageID sets the "last_read_message_id" field.
+func (u *ChatMemberUpsertBulk) SetLastReadMessageID(v int) *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.SetLastReadMessageID(v)
+	})
+}
+
+// AddLastReadMessageID adds v to the "last_read_message_id" field.
+func (u *ChatMemberUpsertBulk) AddLastReadMessageID(v int) *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.AddLastReadMessageID(v)
+	})
+}
+
+// UpdateLastReadMessageID sets the "last_read_message_id" field to the value that was provided on create.
+func (u *ChatMemberUpsertBulk) UpdateLastReadMessageID() *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.UpdateLastReadMessageID()
+	})
+}
+
+// ClearLastReadMessageID clears the value of the "last_read_message_id" field.
+func (u *ChatMemberUpsertBulk) ClearLastReadMessageID() *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.ClearLastReadMessageID()
+	})
+}
+
+// SetLastReadAt sets the "last_read_at" field.
+func (u *ChatMemberUpsertBulk) SetLastReadAt(v time.Time) *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.SetLastReadAt(v)
+	})
+}
+
+// UpdateLastReadAt sets the "last_read_at" field to the value that was provided on create.
+func (u *ChatMemberUpsertBulk) UpdateLastReadAt() *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.UpdateLastReadAt()
+	})
+}
+
+// ClearLastReadAt clears the value of the "last_read_at" field.
+func (u *ChatMemberUpsertBulk) ClearLastReadAt() *ChatMemberUpsertBulk {
+	return u.Update(func(s *ChatMemberUpsert) {
+		s.ClearLastReadAt()
+	})
+}
+
 // Exec executes the query.
 func (u *ChatMemberUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
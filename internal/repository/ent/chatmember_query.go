@@ -27,6 +27,7 @@ type ChatMemberQuery struct {
 	withUser   *UserQuery
 	withChat   *ChatQuery
 	withFKs    bool
+	modifiers  []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -302,8 +303,9 @@ func (_q *ChatMemberQuery) Clone() *ChatMemberQuery {
 		withUser:   _q.withUser.Clone(),
 		withChat:   _q.withChat.Clone(),
 		// clone intermediate query.
-		sql:  _q.sql.Clone(),
-		path: _q.path,
+		sql:       _q.sql.Clone(),
+		path:      _q.path,
+		modifiers: append([]func(*sql.Selector){}, _q.modifiers...),
 	}
 }
 
@@ -428,6 +430,9 @@ func (_q *ChatMemberQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*C
 		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	for i := range hooks {
 		hooks[i](ctx, _spec)
 	}
@@ -519,6 +524,9 @@ func (_q *ChatMemberQuery) loadChat(ctx context.Context, query *ChatQuery, nodes
 
 func (_q *ChatMemberQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	_spec.Node.Columns = _q.ctx.Fields
 	if len(_q.ctx.Fields) > 0 {
 		_spec.Unique = _q.ctx.Unique != nil && *_q.ctx.Unique
@@ -581,6 +589,9 @@ func (_q *ChatMemberQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	if _q.ctx.Unique != nil && *_q.ctx.Unique {
 		selector.Distinct()
 	}
+	for _, m := range _q.modifiers {
+		m(selector)
+	}
 	for _, p := range _q.predicates {
 		p(selector)
 	}
@@ -598,6 +609,12 @@ func (_q *ChatMemberQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// Modify adds a query modifier for attaching custom logic to queries.
+func (_q *ChatMemberQuery) Modify(modifiers ...func(s *sql.Selector)) *ChatMemberSelect {
+	_q.modifiers = append(_q.modifiers, modifiers...)
+	return _q.Select()
+}
+
 // ChatMemberGroupBy is the group-by builder for ChatMember entities.
 type ChatMemberGroupBy struct {
 	selector
@@ -687,3 +704,9 @@ func (_s *ChatMemberSelect) sqlScan(ctx context.Context, root *ChatMemberQuery,
 	defer rows.Close()
 	return sql.ScanSlice(rows, v)
 }
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (_s *ChatMemberSelect) Modify(modifiers ...func(s *sql.Selector)) *ChatMemberSelect {
+	_s.modifiers = append(_s.modifiers, modifiers...)
+	return _s
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -19,8 +20,9 @@ import (
 // ChatMemberUpdate is the builder for updating ChatMember entities.
 type ChatMemberUpdate struct {
 	config
-	hooks    []Hook
-	mutation *ChatMemberMutation
+	hooks     []Hook
+	mutation  *ChatMemberMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // Where appends a list predicates to the ChatMemberUpdate builder.
@@ -43,6 +45,53 @@ func (_u *ChatMemberUpdate) SetNillableIsAdmin(v *bool) *ChatMemberUpdate {
 	return _u
 }
 
+// SetLastReadMessageID sets the "last_read_message_id" field.
+func (_u *ChatMemberUpdate) SetLastReadMessageID(v int) *ChatMemberUpdate {
+	_u.mutation.ResetLastReadMessageID()
+	_u.mutation.SetLastReadMessageID(v)
+	return _u
+}
+
+// SetNillableLastReadMessageID sets the "last_read_message_id" field if the given value is not nil.
+func (_u *ChatMemberUpdate) SetNillableLastReadMessageID(v *int) *ChatMemberUpdate {
+	if v != nil {
+		_u.SetLastReadMessageID(*v)
+	}
+	return _u
+}
+
+// AddLastReadMessageID adds value to the "last_read_message_id" field.
+func (_u *ChatMemberUpdate) AddLastReadMessageID(v int) *ChatMemberUpdate {
+	_u.mutation.AddLastReadMessageID(v)
+	return _u
+}
+
+// ClearLastReadMessageID clears the value of the "last_read_message_id" field.
+func (_u *ChatMemberUpdate) ClearLastReadMessageID() *ChatMemberUpdate {
+	_u.mutation.ClearLastReadMessageID()
+	return _u
+}
+
+// SetLastReadAt sets the "last_read_at" field.
+func (_u *ChatMemberUpdate) SetLastReadAt(v time.Time) *ChatMemberUpdate {
+	_u.mutation.SetLastReadAt(v)
+	return _u
+}
+
+// SetNillableLastReadAt sets the "last_read_at" field if the given value is not nil.
+func (_u *ChatMemberUpdate) SetNillableLastReadAt(v *time.Time) *ChatMemberUpdate {
+	if v != nil {
+		_u.SetLastReadAt(*v)
+	}
+	return _u
+}
+
+// ClearLastReadAt clears the value of the "last_read_at" field.
+func (_u *ChatMemberUpdate) ClearLastReadAt() *ChatMemberUpdate {
+	_u.mutation.ClearLastReadAt()
+	return _u
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_u *ChatMemberUpdate) SetUserID(id int) *ChatMemberUpdate {
 	_u.mutation.SetUserID(id)
@@ -120,6 +169,12 @@ func (_u *ChatMemberUpdate) check() error {
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *ChatMemberUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *ChatMemberUpdate {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
 func (_u *ChatMemberUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if err := _u.check(); err != nil {
 		return _node, err
@@ -135,6 +190,21 @@ func (_u *ChatMemberUpdate) sqlSave(ctx context.Context) (_node int, err error)
 	if value, ok := _u.mutation.IsAdmin(); ok {
 		_spec.SetField(chatmember.FieldIsAdmin, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.LastReadMessageID(); ok {
+		_spec.SetField(chatmember.FieldLastReadMessageID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedLastReadMessageID(); ok {
+		_spec.AddField(chatmember.FieldLastReadMessageID, field.TypeInt, value)
+	}
+	if _u.mutation.LastReadMessageIDCleared() {
+		_spec.ClearField(chatmember.FieldLastReadMessageID, field.TypeInt)
+	}
+	if value, ok := _u.mutation.LastReadAt(); ok {
+		_spec.SetField(chatmember.FieldLastReadAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastReadAtCleared() {
+		_spec.ClearField(chatmember.FieldLastReadAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -193,6 +263,7 @@ func (_u *ChatMemberUpdate) sqlSave(ctx context.Context) (_node int, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	_spec.AddModifiers(_u.modifiers...)
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{chatmember.Label}
@@ -208,9 +279,10 @@ func (_u *ChatMemberUpdate) sqlSave(ctx context.Context) (_node int, err error)
 // ChatMemberUpdateOne is the builder for updating a single ChatMember entity.
 type ChatMemberUpdateOne struct {
 	config
-	fields   []string
-	hooks    []Hook
-	mutation *ChatMemberMutation
+	fields    []string
+	hooks     []Hook
+	mutation  *ChatMemberMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // SetIsAdmin sets the "is_admin" field.
@@ -227,6 +299,53 @@ func (_u *ChatMemberUpdateOne) SetNillableIsAdmin(v *bool) *ChatMemberUpdateOne
 	return _u
 }
 
+// SetLastReadMessageID sets the "last_read_message_id" field.
+func (_u *ChatMemberUpdateOne) SetLastReadMessageID(v int) *ChatMemberUpdateOne {
+	_u.mutation.ResetLastReadMessageID()
+	_u.mutation.SetLastReadMessageID(v)
+	return _u
+}
+
+// SetNillableLastReadMessageID sets the "last_read_message_id" field if the given value is not nil.
+func (_u *ChatMemberUpdateOne) SetNillableLastReadMessageID(v *int) *ChatMemberUpdateOne {
+	if v != nil {
+		_u.SetLastReadMessageID(*v)
+	}
+	return _u
+}
+
+// AddLastReadMessageID adds value to the "last_read_message_id" field.
+func (_u *ChatMemberUpdateOne) AddLastReadMessageID(v int) *ChatMemberUpdateOne {
+	_u.mutation.AddLastReadMessageID(v)
+	return _u
+}
+
+// ClearLastReadMessageID clears the value of the "last_read_message_id" field.
+func (_u *ChatMemberUpdateOne) ClearLastReadMessageID() *ChatMemberUpdateOne {
+	_u.mutation.ClearLastReadMessageID()
+	return _u
+}
+
+// SetLastReadAt sets the "last_read_at" field.
+func (_u *ChatMemberUpdateOne) SetLastReadAt(v time.Time) *ChatMemberUpdateOne {
+	_u.mutation.SetLastReadAt(v)
+	return _u
+}
+
+// SetNillableLastReadAt sets the "last_read_at" field if the given value is not nil.
+func (_u *ChatMemberUpdateOne) SetNillableLastReadAt(v *time.Time) *ChatMemberUpdateOne {
+	if v != nil {
+		_u.SetLastReadAt(*v)
+	}
+	return _u
+}
+
+// ClearLastReadAt clears the value of the "last_read_at" field.
+func (_u *ChatMemberUpdateOne) ClearLastReadAt() *ChatMemberUpdateOne {
+	_u.mutation.ClearLastReadAt()
+	return _u
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_u *ChatMemberUpdateOne) SetUserID(id int) *ChatMemberUpdateOne {
 	_u.mutation.SetUserID(id)
@@ -317,6 +436,12 @@ func (_u *ChatMemberUpdateOne) check() error {
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *ChatMemberUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *ChatMemberUpdateOne {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
 func (_u *ChatMemberUpdateOne) sqlSave(ctx context.Context) (_node *ChatMember, err error) {
 	if err := _u.check(); err != nil {
 		return _node, err
@@ -349,6 +474,21 @@ func (_u *ChatMemberUpdateOne) sqlSave(ctx context.Context) (_node *ChatMember,
 	if value, ok := _u.mutation.IsAdmin(); ok {
 		_spec.SetField(chatmember.FieldIsAdmin, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.LastReadMessageID(); ok {
+		_spec.SetField(chatmember.FieldLastReadMessageID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedLastReadMessageID(); ok {
+		_spec.AddField(chatmember.FieldLastReadMessageID, field.TypeInt, value)
+	}
+	if _u.mutation.LastReadMessageIDCleared() {
+		_spec.ClearField(chatmember.FieldLastReadMessageID, field.TypeInt)
+	}
+	if value, ok := _u.mutation.LastReadAt(); ok {
+		_spec.SetField(chatmember.FieldLastReadAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastReadAtCleared() {
+		_spec.ClearField(chatmember.FieldLastReadAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -407,6 +547,7 @@ func (_u *ChatMemberUpdateOne) sqlSave(ctx context.Context) (_node *ChatMember,
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	_spec.AddModifiers(_u.modifiers...)
 	_node = &ChatMember{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
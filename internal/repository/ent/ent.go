@@ -12,10 +12,20 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/apitoken"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/auditlog"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/identity"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/messagedelivery"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/reaction"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/recoverycode"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/session"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/userevent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/usertoken"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -76,10 +86,20 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			chat.Table:       chat.ValidColumn,
-			chatmember.Table: chatmember.ValidColumn,
-			message.Table:    message.ValidColumn,
-			user.Table:       user.ValidColumn,
+			apitoken.Table:        apitoken.ValidColumn,
+			auditlog.Table:        auditlog.ValidColumn,
+			chat.Table:            chat.ValidColumn,
+			chatemoji.Table:       chatemoji.ValidColumn,
+			chatmember.Table:      chatmember.ValidColumn,
+			identity.Table:        identity.ValidColumn,
+			message.Table:         message.ValidColumn,
+			messagedelivery.Table: messagedelivery.ValidColumn,
+			reaction.Table:        reaction.ValidColumn,
+			recoverycode.Table:    recoverycode.ValidColumn,
+			session.Table:         session.ValidColumn,
+			user.Table:            user.ValidColumn,
+			userevent.Table:       userevent.ValidColumn,
+			usertoken.Table:       usertoken.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
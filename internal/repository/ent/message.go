@@ -29,10 +29,11 @@ type Message struct {
 	IsEdited bool `json:"is_edited,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the MessageQuery when eager-loading is set.
-	Edges         MessageEdges `json:"edges"`
-	chat_messages *int
-	user_messages *int
-	selectValues  sql.SelectValues
+	Edges           MessageEdges `json:"edges"`
+	chat_messages   *int
+	message_replies *int
+	user_messages   *int
+	selectValues    sql.SelectValues
 }
 
 // MessageEdges holds the relations/edges for other nodes in the graph.
@@ -41,9 +42,17 @@ type MessageEdges struct {
 	Sender *User `json:"sender,omitempty"`
 	// Chat holds the value of the chat edge.
 	Chat *Chat `json:"chat,omitempty"`
+	// Deliveries holds the value of the deliveries edge.
+	Deliveries []*MessageDelivery `json:"deliveries,omitempty"`
+	// Reactions holds the value of the reactions edge.
+	Reactions []*Reaction `json:"reactions,omitempty"`
+	// ReplyTo holds the value of the reply_to edge.
+	ReplyTo *Message `json:"reply_to,omitempty"`
+	// Replies holds the value of the replies edge.
+	Replies []*Message `json:"replies,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [6]bool
 }
 
 // SenderOrErr returns the Sender value or an error if the edge
@@ -68,6 +77,44 @@ func (e MessageEdges) ChatOrErr() (*Chat, error) {
 	return nil, &NotLoadedError{edge: "chat"}
 }
 
+// DeliveriesOrErr returns the Deliveries value or an error if the edge
+// was not loaded in eager-loading.
+func (e MessageEdges) DeliveriesOrErr() ([]*MessageDelivery, error) {
+	if e.loadedTypes[2] {
+		return e.Deliveries, nil
+	}
+	return nil, &NotLoadedError{edge: "deliveries"}
+}
+
+// ReactionsOrErr returns the Reactions value or an error if the edge
+// was not loaded in eager-loading.
+func (e MessageEdges) ReactionsOrErr() ([]*Reaction, error) {
+	if e.loadedTypes[3] {
+		return e.Reactions, nil
+	}
+	return nil, &NotLoadedError{edge: "reactions"}
+}
+
+// ReplyToOrErr returns the ReplyTo value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e MessageEdges) ReplyToOrErr() (*Message, error) {
+	if e.ReplyTo != nil {
+		return e.ReplyTo, nil
+	} else if e.loadedTypes[4] {
+		return nil, &NotFoundError{label: message.Label}
+	}
+	return nil, &NotLoadedError{edge: "reply_to"}
+}
+
+// RepliesOrErr returns the Replies value or an error if the edge
+// was not loaded in eager-loading.
+func (e MessageEdges) RepliesOrErr() ([]*Message, error) {
+	if e.loadedTypes[5] {
+		return e.Replies, nil
+	}
+	return nil, &NotLoadedError{edge: "replies"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Message) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -83,7 +130,9 @@ func (*Message) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullTime)
 		case message.ForeignKeys[0]: // chat_messages
 			values[i] = new(sql.NullInt64)
-		case message.ForeignKeys[1]: // user_messages
+		case message.ForeignKeys[1]: // message_replies
+			values[i] = new(sql.NullInt64)
+		case message.ForeignKeys[2]: // user_messages
 			values[i] = new(sql.NullInt64)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -138,6 +187,13 @@ func (_m *Message) assignValues(columns []string, values []any) error {
 				*_m.chat_messages = int(value.Int64)
 			}
 		case message.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field message_replies", value)
+			} else if value.Valid {
+				_m.message_replies = new(int)
+				*_m.message_replies = int(value.Int64)
+			}
+		case message.ForeignKeys[2]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field user_messages", value)
 			} else if value.Valid {
@@ -167,6 +223,26 @@ func (_m *Message) QueryChat() *ChatQuery {
 	return NewMessageClient(_m.config).QueryChat(_m)
 }
 
+// QueryDeliveries queries the "deliveries" edge of the Message entity.
+func (_m *Message) QueryDeliveries() *MessageDeliveryQuery {
+	return NewMessageClient(_m.config).QueryDeliveries(_m)
+}
+
+// QueryReactions queries the "reactions" edge of the Message entity.
+func (_m *Message) QueryReactions() *ReactionQuery {
+	return NewMessageClient(_m.config).QueryReactions(_m)
+}
+
+// QueryReplyTo queries the "reply_to" edge of the Message entity.
+func (_m *Message) QueryReplyTo() *MessageQuery {
+	return NewMessageClient(_m.config).QueryReplyTo(_m)
+}
+
+// QueryReplies queries the "replies" edge of the Message entity.
+func (_m *Message) QueryReplies() *MessageQuery {
+	return NewMessageClient(_m.config).QueryReplies(_m)
+}
+
 // Update returns a builder for updating this Message.
 // Note that you need to call Message.Unwrap() before calling this method if this Message
 // was returned from a transaction, and the transaction was committed or rolled back.
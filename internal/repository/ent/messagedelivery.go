@@ -0,0 +1,172 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/messagedelivery"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+)
+
+// MessageDelivery is the model entity for the MessageDelivery schema.
+type MessageDelivery struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// DeliveredAt holds the value of the "delivered_at" field.
+	DeliveredAt time.Time `json:"delivered_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the MessageDeliveryQuery when eager-loading is set.
+	Edges                   MessageDeliveryEdges `json:"edges"`
+	message_deliveries      *int
+	user_message_deliveries *int
+	selectValues            sql.SelectValues
+}
+
+// MessageDeliveryEdges holds the relations/edges for other nodes in the graph.
+type MessageDeliveryEdges struct {
+	// Message holds the value of the message edge.
+	Message *Message `json:"message,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// MessageOrErr returns the Message value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e MessageDeliveryEdges) MessageOrErr() (*Message, error) {
+	if e.Message != nil {
+		return e.Message, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: message.Label}
+	}
+	return nil, &NotLoadedError{edge: "message"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e MessageDeliveryEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*MessageDelivery) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case messagedelivery.FieldID:
+			values[i] = new(sql.NullInt64)
+		case messagedelivery.FieldDeliveredAt:
+			values[i] = new(sql.NullTime)
+		case messagedelivery.ForeignKeys[0]: // message_deliveries
+			values[i] = new(sql.NullInt64)
+		case messagedelivery.ForeignKeys[1]: // user_message_deliveries
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the MessageDelivery fields.
+func (_m *MessageDelivery) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case messagedelivery.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case messagedelivery.FieldDeliveredAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field delivered_at", values[i])
+			} else if value.Valid {
+				_m.DeliveredAt = value.Time
+			}
+		case messagedelivery.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field message_deliveries", value)
+			} else if value.Valid {
+				_m.message_deliveries = new(int)
+				*_m.message_deliveries = int(value.Int64)
+			}
+		case messagedelivery.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_message_deliveries", value)
+			} else if value.Valid {
+				_m.user_message_deliveries = new(int)
+				*_m.user_message_deliveries = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the MessageDelivery.
+// This includes values selected through modifiers, order, etc.
+func (_m *MessageDelivery) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryMessage queries the "message" edge of the MessageDelivery entity.
+func (_m *MessageDelivery) QueryMessage() *MessageQuery {
+	return NewMessageDeliveryClient(_m.config).QueryMessage(_m)
+}
+
+// QueryUser queries the "user" edge of the MessageDelivery entity.
+func (_m *MessageDelivery) QueryUser() *UserQuery {
+	return NewMessageDeliveryClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this MessageDelivery.
+// Note that you need to call MessageDelivery.Unwrap() before calling this method if this MessageDelivery
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *MessageDelivery) Update() *MessageDeliveryUpdateOne {
+	return NewMessageDeliveryClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the MessageDelivery entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *MessageDelivery) Unwrap() *MessageDelivery {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: MessageDelivery is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *MessageDelivery) String() string {
+	var builder strings.Builder
+	builder.WriteString("MessageDelivery(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("delivered_at=")
+	builder.WriteString(_m.DeliveredAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// MessageDeliveries is a parsable slice of MessageDelivery.
+type MessageDeliveries []*MessageDelivery
@@ -5,10 +5,20 @@ package ent
 import (
 	"time"
 
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/apitoken"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/auditlog"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/identity"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/messagedelivery"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/reaction"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/recoverycode"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/session"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/userevent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/usertoken"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/schema"
 )
 
@@ -16,6 +26,80 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apitokenFields := schema.APIToken{}.Fields()
+	_ = apitokenFields
+	// apitokenDescName is the schema descriptor for name field.
+	apitokenDescName := apitokenFields[0].Descriptor()
+	// apitoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apitoken.NameValidator = func() func(string) error {
+		validators := apitokenDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// apitokenDescTokenHash is the schema descriptor for token_hash field.
+	apitokenDescTokenHash := apitokenFields[1].Descriptor()
+	// apitoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	apitoken.TokenHashValidator = apitokenDescTokenHash.Validators[0].(func(string) error)
+	// apitokenDescPrefix is the schema descriptor for prefix field.
+	apitokenDescPrefix := apitokenFields[2].Descriptor()
+	// apitoken.PrefixValidator is a validator for the "prefix" field. It is called by the builders before save.
+	apitoken.PrefixValidator = func() func(string) error {
+		validators := apitokenDescPrefix.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(prefix string) error {
+			for _, fn := range fns {
+				if err := fn(prefix); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// apitokenDescCreatedAt is the schema descriptor for created_at field.
+	apitokenDescCreatedAt := apitokenFields[5].Descriptor()
+	// apitoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apitoken.DefaultCreatedAt = apitokenDescCreatedAt.Default.(func() time.Time)
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescAction is the schema descriptor for action field.
+	auditlogDescAction := auditlogFields[0].Descriptor()
+	// auditlog.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditlog.ActionValidator = func() func(string) error {
+		validators := auditlogDescAction.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(action string) error {
+			for _, fn := range fns {
+				if err := fn(action); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// auditlogDescIPAddress is the schema descriptor for ip_address field.
+	auditlogDescIPAddress := auditlogFields[1].Descriptor()
+	// auditlog.IPAddressValidator is a validator for the "ip_address" field. It is called by the builders before save.
+	auditlog.IPAddressValidator = auditlogDescIPAddress.Validators[0].(func(string) error)
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[3].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() time.Time)
 	chatFields := schema.Chat{}.Fields()
 	_ = chatFields
 	// chatDescName is the schema descriptor for name field.
@@ -36,6 +120,34 @@ func init() {
 	chat.DefaultUpdatedAt = chatDescUpdatedAt.Default.(func() time.Time)
 	// chat.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	chat.UpdateDefaultUpdatedAt = chatDescUpdatedAt.UpdateDefault.(func() time.Time)
+	chatemojiFields := schema.ChatEmoji{}.Fields()
+	_ = chatemojiFields
+	// chatemojiDescName is the schema descriptor for name field.
+	chatemojiDescName := chatemojiFields[0].Descriptor()
+	// chatemoji.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	chatemoji.NameValidator = chatemojiDescName.Validators[0].(func(string) error)
+	// chatemojiDescImageURL is the schema descriptor for image_url field.
+	chatemojiDescImageURL := chatemojiFields[1].Descriptor()
+	// chatemoji.ImageURLValidator is a validator for the "image_url" field. It is called by the builders before save.
+	chatemoji.ImageURLValidator = func() func(string) error {
+		validators := chatemojiDescImageURL.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(image_url string) error {
+			for _, fn := range fns {
+				if err := fn(image_url); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// chatemojiDescCreatedAt is the schema descriptor for created_at field.
+	chatemojiDescCreatedAt := chatemojiFields[2].Descriptor()
+	// chatemoji.DefaultCreatedAt holds the default value on creation for the created_at field.
+	chatemoji.DefaultCreatedAt = chatemojiDescCreatedAt.Default.(func() time.Time)
 	chatmemberFields := schema.ChatMember{}.Fields()
 	_ = chatmemberFields
 	// chatmemberDescJoinedAt is the schema descriptor for joined_at field.
@@ -46,6 +158,52 @@ func init() {
 	chatmemberDescIsAdmin := chatmemberFields[1].Descriptor()
 	// chatmember.DefaultIsAdmin holds the default value on creation for the is_admin field.
 	chatmember.DefaultIsAdmin = chatmemberDescIsAdmin.Default.(bool)
+	identityFields := schema.Identity{}.Fields()
+	_ = identityFields
+	// identityDescProvider is the schema descriptor for provider field.
+	identityDescProvider := identityFields[0].Descriptor()
+	// identity.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	identity.ProviderValidator = func() func(string) error {
+		validators := identityDescProvider.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(provider string) error {
+			for _, fn := range fns {
+				if err := fn(provider); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// identityDescSubject is the schema descriptor for subject field.
+	identityDescSubject := identityFields[1].Descriptor()
+	// identity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	identity.SubjectValidator = func() func(string) error {
+		validators := identityDescSubject.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(subject string) error {
+			for _, fn := range fns {
+				if err := fn(subject); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// identityDescEmail is the schema descriptor for email field.
+	identityDescEmail := identityFields[2].Descriptor()
+	// identity.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	identity.EmailValidator = identityDescEmail.Validators[0].(func(string) error)
+	// identityDescCreatedAt is the schema descriptor for created_at field.
+	identityDescCreatedAt := identityFields[3].Descriptor()
+	// identity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	identity.DefaultCreatedAt = identityDescCreatedAt.Default.(func() time.Time)
 	messageFields := schema.Message{}.Fields()
 	_ = messageFields
 	// messageDescContent is the schema descriptor for content field.
@@ -66,6 +224,72 @@ func init() {
 	messageDescIsEdited := messageFields[3].Descriptor()
 	// message.DefaultIsEdited holds the default value on creation for the is_edited field.
 	message.DefaultIsEdited = messageDescIsEdited.Default.(bool)
+	messagedeliveryFields := schema.MessageDelivery{}.Fields()
+	_ = messagedeliveryFields
+	// messagedeliveryDescDeliveredAt is the schema descriptor for delivered_at field.
+	messagedeliveryDescDeliveredAt := messagedeliveryFields[0].Descriptor()
+	// messagedelivery.DefaultDeliveredAt holds the default value on creation for the delivered_at field.
+	messagedelivery.DefaultDeliveredAt = messagedeliveryDescDeliveredAt.Default.(func() time.Time)
+	reactionFields := schema.Reaction{}.Fields()
+	_ = reactionFields
+	// reactionDescEmoji is the schema descriptor for emoji field.
+	reactionDescEmoji := reactionFields[0].Descriptor()
+	// reaction.EmojiValidator is a validator for the "emoji" field. It is called by the builders before save.
+	reaction.EmojiValidator = func() func(string) error {
+		validators := reactionDescEmoji.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(emoji string) error {
+			for _, fn := range fns {
+				if err := fn(emoji); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// reactionDescCreatedAt is the schema descriptor for created_at field.
+	reactionDescCreatedAt := reactionFields[1].Descriptor()
+	// reaction.DefaultCreatedAt holds the default value on creation for the created_at field.
+	reaction.DefaultCreatedAt = reactionDescCreatedAt.Default.(func() time.Time)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	recoverycodeDescCodeHash := recoverycodeFields[0].Descriptor()
+	// recoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	recoverycode.CodeHashValidator = recoverycodeDescCodeHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[1].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescDeviceName is the schema descriptor for device_name field.
+	sessionDescDeviceName := sessionFields[0].Descriptor()
+	// session.DeviceNameValidator is a validator for the "device_name" field. It is called by the builders before save.
+	session.DeviceNameValidator = sessionDescDeviceName.Validators[0].(func(string) error)
+	// sessionDescIPAddress is the schema descriptor for ip_address field.
+	sessionDescIPAddress := sessionFields[1].Descriptor()
+	// session.IPAddressValidator is a validator for the "ip_address" field. It is called by the builders before save.
+	session.IPAddressValidator = sessionDescIPAddress.Validators[0].(func(string) error)
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[2].Descriptor()
+	// session.UserAgentValidator is a validator for the "user_agent" field. It is called by the builders before save.
+	session.UserAgentValidator = sessionDescUserAgent.Validators[0].(func(string) error)
+	// sessionDescRefreshGeneration is the schema descriptor for refresh_generation field.
+	sessionDescRefreshGeneration := sessionFields[3].Descriptor()
+	// session.DefaultRefreshGeneration holds the default value on creation for the refresh_generation field.
+	session.DefaultRefreshGeneration = sessionDescRefreshGeneration.Default.(int)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[5].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescLastUsedAt is the schema descriptor for last_used_at field.
+	sessionDescLastUsedAt := sessionFields[6].Descriptor()
+	// session.DefaultLastUsedAt holds the default value on creation for the last_used_at field.
+	session.DefaultLastUsedAt = sessionDescLastUsedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescUsername is the schema descriptor for username field.
@@ -91,17 +315,85 @@ func init() {
 	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
 	user.PasswordValidator = userDescPassword.Validators[0].(func(string) error)
 	// userDescDisplayName is the schema descriptor for display_name field.
-	userDescDisplayName := userFields[2].Descriptor()
+	userDescDisplayName := userFields[3].Descriptor()
 	// user.DisplayNameValidator is a validator for the "display_name" field. It is called by the builders before save.
 	user.DisplayNameValidator = userDescDisplayName.Validators[0].(func(string) error)
+	// userDescEmail is the schema descriptor for email field.
+	userDescEmail := userFields[4].Descriptor()
+	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[3].Descriptor()
+	userDescCreatedAt := userFields[6].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[4].Descriptor()
+	userDescUpdatedAt := userFields[7].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// userDescHideLastSeen is the schema descriptor for hide_last_seen field.
+	userDescHideLastSeen := userFields[9].Descriptor()
+	// user.DefaultHideLastSeen holds the default value on creation for the hide_last_seen field.
+	user.DefaultHideLastSeen = userDescHideLastSeen.Default.(bool)
+	// userDescHideReadReceipts is the schema descriptor for hide_read_receipts field.
+	userDescHideReadReceipts := userFields[10].Descriptor()
+	// user.DefaultHideReadReceipts holds the default value on creation for the hide_read_receipts field.
+	user.DefaultHideReadReceipts = userDescHideReadReceipts.Default.(bool)
+	// userDescTotpEnabled is the schema descriptor for totp_enabled field.
+	userDescTotpEnabled := userFields[12].Descriptor()
+	// user.DefaultTotpEnabled holds the default value on creation for the totp_enabled field.
+	user.DefaultTotpEnabled = userDescTotpEnabled.Default.(bool)
+	// userDescEventSeq is the schema descriptor for event_seq field.
+	userDescEventSeq := userFields[14].Descriptor()
+	// user.DefaultEventSeq holds the default value on creation for the event_seq field.
+	user.DefaultEventSeq = userDescEventSeq.Default.(int64)
+	usereventFields := schema.UserEvent{}.Fields()
+	_ = usereventFields
+	// usereventDescSeq is the schema descriptor for seq field.
+	usereventDescSeq := usereventFields[0].Descriptor()
+	// userevent.SeqValidator is a validator for the "seq" field. It is called by the builders before save.
+	userevent.SeqValidator = usereventDescSeq.Validators[0].(func(int64) error)
+	// usereventDescType is the schema descriptor for type field.
+	usereventDescType := usereventFields[1].Descriptor()
+	// userevent.TypeValidator is a validator for the "type" field. It is called by the builders before save.
+	userevent.TypeValidator = func() func(string) error {
+		validators := usereventDescType.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(_type string) error {
+			for _, fn := range fns {
+				if err := fn(_type); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// usereventDescCreatedAt is the schema descriptor for created_at field.
+	usereventDescCreatedAt := usereventFields[3].Descriptor()
+	// userevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	userevent.DefaultCreatedAt = usereventDescCreatedAt.Default.(func() time.Time)
+	usertokenFields := schema.UserToken{}.Fields()
+	_ = usertokenFields
+	// usertokenDescTokenHash is the schema descriptor for token_hash field.
+	usertokenDescTokenHash := usertokenFields[1].Descriptor()
+	// usertoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	usertoken.TokenHashValidator = usertokenDescTokenHash.Validators[0].(func(string) error)
+	// usertokenDescEmail is the schema descriptor for email field.
+	usertokenDescEmail := usertokenFields[2].Descriptor()
+	// usertoken.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	usertoken.EmailValidator = usertokenDescEmail.Validators[0].(func(string) error)
+	// usertokenDescCreatedAt is the schema descriptor for created_at field.
+	usertokenDescCreatedAt := usertokenFields[4].Descriptor()
+	// usertoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	usertoken.DefaultCreatedAt = usertokenDescCreatedAt.Default.(func() time.Time)
+	// usertokenDescAttempts is the schema descriptor for attempts field.
+	usertokenDescAttempts := usertokenFields[6].Descriptor()
+	// usertoken.DefaultAttempts holds the default value on creation for the attempts field.
+	usertoken.DefaultAttempts = usertokenDescAttempts.Default.(int)
+	// usertoken.AttemptsValidator is a validator for the "attempts" field. It is called by the builders before save.
+	usertoken.AttemptsValidator = usertokenDescAttempts.Validators[0].(func(int) error)
 }
@@ -14,14 +14,34 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// APIToken is the client for interacting with the APIToken builders.
+	APIToken *APITokenClient
+	// AuditLog is the client for interacting with the AuditLog builders.
+	AuditLog *AuditLogClient
 	// Chat is the client for interacting with the Chat builders.
 	Chat *ChatClient
+	// ChatEmoji is the client for interacting with the ChatEmoji builders.
+	ChatEmoji *ChatEmojiClient
 	// ChatMember is the client for interacting with the ChatMember builders.
 	ChatMember *ChatMemberClient
+	// Identity is the client for interacting with the Identity builders.
+	Identity *IdentityClient
 	// Message is the client for interacting with the Message builders.
 	Message *MessageClient
+	// MessageDelivery is the client for interacting with the MessageDelivery builders.
+	MessageDelivery *MessageDeliveryClient
+	// Reaction is the client for interacting with the Reaction builders.
+	Reaction *ReactionClient
+	// RecoveryCode is the client for interacting with the RecoveryCode builders.
+	RecoveryCode *RecoveryCodeClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
+	// UserEvent is the client for interacting with the UserEvent builders.
+	UserEvent *UserEventClient
+	// UserToken is the client for interacting with the UserToken builders.
+	UserToken *UserTokenClient
 
 	// lazily loaded.
 	client     *Client
@@ -153,10 +173,20 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.APIToken = NewAPITokenClient(tx.config)
+	tx.AuditLog = NewAuditLogClient(tx.config)
 	tx.Chat = NewChatClient(tx.config)
+	tx.ChatEmoji = NewChatEmojiClient(tx.config)
 	tx.ChatMember = NewChatMemberClient(tx.config)
+	tx.Identity = NewIdentityClient(tx.config)
 	tx.Message = NewMessageClient(tx.config)
+	tx.MessageDelivery = NewMessageDeliveryClient(tx.config)
+	tx.Reaction = NewReactionClient(tx.config)
+	tx.RecoveryCode = NewRecoveryCodeClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
 	tx.User = NewUserClient(tx.config)
+	tx.UserEvent = NewUserEventClient(tx.config)
+	tx.UserToken = NewUserTokenClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -166,7 +196,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Chat.QueryXXX(), the query will be executed
+// applies a query, for example: APIToken.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -1,3 +1,3 @@
 package repository
 
-//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate --target ./ent --feature sql/execquery,sql/upsert ./schema
+//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate --target ./ent --feature sql/execquery,sql/modifier,sql/upsert ./schema
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// APIToken holds the schema definition for the APIToken entity.
+// API tokens are long-lived credentials of bot accounts, limited to a set of
+// scopes. Only their hash is stored; the prefix identifies a token in lists.
+type APIToken struct {
+	ent.Schema
+}
+
+// Fields of the APIToken.
+func (APIToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			MaxLen(100),
+		field.String("token_hash").
+			Sensitive().
+			Unique().
+			NotEmpty(),
+		field.String("prefix").
+			NotEmpty().
+			MaxLen(16),
+		field.Strings("scopes"),
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the APIToken.
+func (APIToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("api_tokens").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the APIToken.
+func (APIToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("user"),
+	}
+}
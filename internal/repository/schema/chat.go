@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 )
@@ -35,7 +36,12 @@ func (Chat) Edges() []ent.Edge {
 		edge.From("creator", User.Type).
 			Ref("created_chats").
 			Unique(),
-		edge.To("messages", Message.Type),
-		edge.To("members", ChatMember.Type),
+		// Messages, memberships and custom emoji are deleted with the chat
+		edge.To("messages", Message.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("members", ChatMember.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("emoji", ChatEmoji.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
 	}
 }
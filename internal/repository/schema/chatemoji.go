@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"regexp"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ChatEmoji holds the schema definition for the ChatEmoji entity: a custom
+// emoji the members of a chat can react with as :name:.
+type ChatEmoji struct {
+	ent.Schema
+}
+
+// Fields of the ChatEmoji.
+func (ChatEmoji) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Match(regexp.MustCompile(`^[a-z0-9_]{2,32}$`)).
+			Immutable(),
+		field.String("image_url").
+			NotEmpty().
+			MaxLen(2048),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the ChatEmoji.
+func (ChatEmoji) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("chat", Chat.Type).
+			Ref("emoji").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the ChatEmoji.
+func (ChatEmoji) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Edges("chat").
+			Unique(),
+	}
+}
@@ -21,6 +21,14 @@ func (ChatMember) Fields() []ent.Field {
 			Immutable(),
 		field.Bool("is_admin").
 			Default(false),
+		// Read cursor: every message up to this one was read. It is not an
+		// edge so it survives the message being deleted.
+		field.Int("last_read_message_id").
+			Optional().
+			Nillable(),
+		field.Time("last_read_at").
+			Optional().
+			Nillable(),
 	}
 }
 
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Identity holds the schema definition for the Identity entity.
+// An identity links a user to an account at an external OIDC provider,
+// identified by the provider name and the subject of its ID tokens.
+type Identity struct {
+	ent.Schema
+}
+
+// Fields of the Identity.
+func (Identity) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("provider").
+			NotEmpty().
+			MaxLen(50),
+		field.String("subject").
+			NotEmpty().
+			MaxLen(255),
+		field.String("email").
+			Optional().
+			MaxLen(254),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_login_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Identity.
+func (Identity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("identities").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Identity.
+func (Identity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("provider", "subject").
+			Unique(),
+		index.Edges("user"),
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // Message holds the schema definition for the Message entity.
@@ -40,5 +42,26 @@ func (Message) Edges() []ent.Edge {
 			Ref("messages").
 			Unique().
 			Required(),
+		edge.To("deliveries", MessageDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("reactions", Reaction.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		// A reply quotes a message of the same chat. Replies outlive the
+		// message they quote.
+		edge.To("replies", Message.Type).
+			Annotations(entsql.OnDelete(entsql.SetNull)).
+			From("reply_to").
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the Message.
+func (Message) Indexes() []ent.Index {
+	return []ent.Index{
+		// Serves the last message and the unread count of each chat
+		index.Edges("chat"),
+		// Serves threads
+		index.Edges("reply_to"),
 	}
 }
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// MessageDelivery holds the schema definition for the MessageDelivery entity.
+// A message is delivered to a recipient once one of their connections
+// received it, or once they fetched it.
+type MessageDelivery struct {
+	ent.Schema
+}
+
+// Fields of the MessageDelivery.
+func (MessageDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("delivered_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the MessageDelivery.
+func (MessageDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("message", Message.Type).
+			Ref("deliveries").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("user", User.Type).
+			Ref("message_deliveries").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the MessageDelivery.
+func (MessageDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("message", "user").
+			Unique(),
+	}
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Reaction holds the schema definition for the Reaction entity. The emoji is
+// either a Unicode emoji or a custom emoji of the chat as :name:.
+type Reaction struct {
+	ent.Schema
+}
+
+// Fields of the Reaction.
+func (Reaction) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("emoji").
+			NotEmpty().
+			MaxLen(64).
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Reaction.
+func (Reaction) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("message", Message.Type).
+			Ref("reactions").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("user", User.Type).
+			Ref("reactions").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the Reaction.
+func (Reaction) Indexes() []ent.Index {
+	return []ent.Index{
+		// A user reacts with each emoji once
+		index.Fields("emoji").
+			Edges("message", "user").
+			Unique(),
+		index.Edges("message"),
+	}
+}
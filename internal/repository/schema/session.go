@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Session holds the schema definition for the Session entity.
+// A session represents one login on one device and owns the refresh
+// token family issued for it.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("device_name").
+			Optional().
+			MaxLen(100),
+		field.String("ip_address").
+			Optional().
+			MaxLen(64),
+		field.String("user_agent").
+			Optional().
+			MaxLen(512),
+		field.Int("refresh_generation").
+			Default(0),
+		field.Time("expires_at"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_used_at").
+			Default(time.Now),
+		// authenticated_at is when the user last proved who they are for
+		// this session, by logging in. Refreshing the tokens keeps it, so it
+		// tells how fresh the login behind a request is.
+		field.Time("authenticated_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Session.
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("user"),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 )
@@ -23,9 +24,20 @@ func (User) Fields() []ent.Field {
 		field.String("password").
 			Sensitive().
 			NotEmpty(),
+		field.Enum("type").
+			Values("user", "bot").
+			Default("user"),
 		field.String("display_name").
 			Optional().
 			MaxLen(100),
+		field.String("email").
+			Optional().
+			Nillable().
+			Unique().
+			MaxLen(254),
+		field.Time("email_verified_at").
+			Optional().
+			Nillable(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -35,6 +47,22 @@ func (User) Fields() []ent.Field {
 		field.Time("last_seen").
 			Optional().
 			Nillable(),
+		// Hides last_seen from other users; the online status stays visible
+		field.Bool("hide_last_seen").
+			Default(false),
+		// Keeps other members from seeing what the user read
+		field.Bool("hide_read_receipts").
+			Default(false),
+		field.String("totp_secret").
+			Optional().
+			Sensitive(),
+		field.Bool("totp_enabled").
+			Default(false),
+		field.Int64("totp_last_step").
+			Optional(),
+		// Last number handed out in the user's real-time event sequence
+		field.Int64("event_seq").
+			Default(0),
 	}
 }
 
@@ -44,5 +72,28 @@ func (User) Edges() []ent.Edge {
 		edge.To("created_chats", Chat.Type),
 		edge.To("messages", Message.Type),
 		edge.To("chat_members", ChatMember.Type),
+		edge.To("sessions", Session.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("recovery_codes", RecoveryCode.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("audit_logs", AuditLog.Type).
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+		edge.To("tokens", UserToken.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("api_tokens", APIToken.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("identities", Identity.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("events", UserEvent.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("message_deliveries", MessageDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("reactions", Reaction.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		// Bots are owned by the user who created them and deleted with them
+		edge.To("bots", User.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			From("owner").
+			Unique(),
 	}
 }
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// UserEvent holds the schema definition for the UserEvent entity.
+// Every real-time event delivered to a user is logged with the next number
+// of the user's event sequence, so reconnecting clients can replay what they
+// missed. Events are pruned after the retention period.
+type UserEvent struct {
+	ent.Schema
+}
+
+// Fields of the UserEvent.
+func (UserEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("seq").
+			Positive().
+			Immutable(),
+		field.String("type").
+			NotEmpty().
+			MaxLen(50).
+			Immutable(),
+		field.JSON("payload", json.RawMessage{}).
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the UserEvent.
+func (UserEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("events").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the UserEvent.
+func (UserEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("seq").
+			Edges("user").
+			Unique(),
+		index.Fields("created_at"),
+	}
+}
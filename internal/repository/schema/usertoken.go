@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// UserToken holds the schema definition for the UserToken entity.
+// User tokens are single-use, expiring tokens sent by email, such as email
+// verification and password reset tokens, and the "MFA pending" tokens of
+// two-step logins. Only their hash is stored.
+type UserToken struct {
+	ent.Schema
+}
+
+// Fields of the UserToken.
+func (UserToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("purpose").
+			Values("email_verification", "password_reset", "mfa_pending"),
+		field.String("token_hash").
+			Sensitive().
+			Unique().
+			NotEmpty(),
+		field.String("email").
+			Optional().
+			MaxLen(254),
+		field.Time("expires_at"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("used_at").
+			Optional().
+			Nillable(),
+		// Codes entered with an mfa_pending token
+		field.Int("attempts").
+			Default(0).
+			NonNegative(),
+	}
+}
+
+// Edges of the UserToken.
+func (UserToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("tokens").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the UserToken.
+func (UserToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("purpose").
+			Edges("user"),
+	}
+}
@@ -8,28 +8,150 @@ import (
 
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/handler"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/hub"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/middleware"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/service"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/database"
 	f "github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/fiber"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/mail"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"github.com/gofiber/fiber/v3/middleware/logger"
 	"github.com/gofiber/fiber/v3/middleware/recover"
+	"github.com/redis/go-redis/v9"
 )
 
 // Server represents the application server
 type Server struct {
-	app         *fiber.App
-	config      *config.Config
-	client      *ent.Client
-	authService *auth.Service
+	app            *fiber.App
+	config         *config.Config
+	client         *ent.Client
+	authService    *auth.Service
+	sessionService *service.SessionService
+	mfaService     *service.MFAService
+	accountService *service.AccountService
+	botService     *service.BotService
+	oidcService    *service.OIDCService
+	loginGuard     *auth.LoginGuard
+	ticketIssuer   *auth.TicketIssuer
+	hub            *hub.Hub
+	broker         hub.Broker
+	brokerDB       *database.DB
+	eventLog       *service.EventLogService
+	deliveries     *service.DeliveryQueue
+	events         *event.Bus
+	stopJobs       context.CancelFunc
+	redis          *redis.Client
 }
 
 // New creates a new server instance
 func New(cfg *config.Config, client *ent.Client) (*Server, error) {
 	// Initialize auth service
-	authService := auth.NewAuthService(cfg.Auth.PasetoKey, cfg.Auth.TokenExpiration)
+	authService, err := auth.NewAuthService(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize auth service: %w", err)
+	}
+	sessionService := service.NewSessionService(client, authService)
+	mfaService := service.NewMFAService(client, authService, cfg.Auth.MFA, auth.SystemClock)
+
+	// Initialize mailer for account emails
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize mailer: %w", err)
+	}
+	accountService := service.NewAccountService(client, authService, sessionService, mailer, cfg.Mail)
+	botService := service.NewBotService(client)
+	oidcService, err := service.NewOIDCService(client, authService, cfg.Auth.OIDC, auth.SystemClock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize single sign-on: %w", err)
+	}
+
+	// Redis is only connected when a store needs it
+	var redisClient *redis.Client
+	getRedis := func() (*redis.Client, error) {
+		if redisClient == nil {
+			client, err := database.NewRedisClient(cfg.Redis)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to redis: %w", err)
+			}
+			redisClient = client
+		}
+		return redisClient, nil
+	}
+
+	// Initialize brute-force protection
+	var attemptStore auth.AttemptStore
+	switch cfg.Auth.LoginProtection.Store {
+	case "memory":
+		attemptStore = auth.NewMemoryAttemptStore(auth.SystemClock)
+	case "redis":
+		client, err := getRedis()
+		if err != nil {
+			return nil, err
+		}
+		attemptStore = auth.NewRedisAttemptStore(client, auth.SystemClock)
+	default:
+		return nil, fmt.Errorf("unknown login protection store %q", cfg.Auth.LoginProtection.Store)
+	}
+	loginGuard := auth.NewLoginGuard(attemptStore, cfg.Auth.LoginProtection, auth.SystemClock)
+
+	// Initialize WebSocket connection tickets
+	var ticketStore auth.TicketStore
+	switch cfg.WebSocket.TicketStore {
+	case "memory":
+		ticketStore = auth.NewMemoryTicketStore(auth.SystemClock)
+	case "redis":
+		client, err := getRedis()
+		if err != nil {
+			return nil, err
+		}
+		ticketStore = auth.NewRedisTicketStore(client)
+	default:
+		return nil, fmt.Errorf("unknown websocket ticket store %q", cfg.WebSocket.TicketStore)
+	}
+	ticketIssuer := auth.NewTicketIssuer(ticketStore, time.Duration(cfg.WebSocket.TicketTTL)*time.Second, auth.SystemClock)
+
+	// Domain events of the services, delivered to WebSocket clients
+	events := event.NewBus()
+
+	eventLog := service.NewEventLogService(client,
+		time.Duration(cfg.WebSocket.EventRetention)*time.Hour, cfg.WebSocket.ReplayLimit)
+
+	// Initialize the hub of live WebSocket connections. The broker lets every
+	// instance deliver events to its own connections.
+	var (
+		broker   hub.Broker
+		brokerDB *database.DB
+	)
+	switch cfg.WebSocket.Broker {
+	case "memory":
+		broker = hub.NewMemoryBroker()
+	case "postgres":
+		brokerDB, err = database.New(cfg.PostgresDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect websocket broker: %w", err)
+		}
+		broker, err = hub.NewPostgresBroker(brokerDB.GetConnection(), cfg.PostgresDB.DSN())
+		if err != nil {
+			_ = brokerDB.Close()
+			return nil, fmt.Errorf("failed to start websocket broker: %w", err)
+		}
+	case "redis":
+		client, err := getRedis()
+		if err != nil {
+			return nil, err
+		}
+		broker = hub.NewRedisBroker(client)
+	default:
+		return nil, fmt.Errorf("unknown websocket broker %q", cfg.WebSocket.Broker)
+	}
+	wsHub, err := hub.New(cfg.WebSocket, broker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid websocket configuration: %w", err)
+	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -39,10 +161,24 @@ func New(cfg *config.Config, client *ent.Client) (*Server, error) {
 	})
 
 	server := &Server{
-		app:         app,
-		config:      cfg,
-		client:      client,
-		authService: authService,
+		app:            app,
+		config:         cfg,
+		client:         client,
+		authService:    authService,
+		sessionService: sessionService,
+		mfaService:     mfaService,
+		accountService: accountService,
+		botService:     botService,
+		oidcService:    oidcService,
+		loginGuard:     loginGuard,
+		ticketIssuer:   ticketIssuer,
+		hub:            wsHub,
+		broker:         broker,
+		brokerDB:       brokerDB,
+		eventLog:       eventLog,
+		deliveries:     service.NewDeliveryQueue(service.NewMessageService(client, events)),
+		events:         events,
+		redis:          redisClient,
 	}
 
 	// Setup middleware and routes
@@ -71,14 +207,39 @@ func (s *Server) setupMiddleware() {
 // setupRoutes configures all application routes
 func (s *Server) setupRoutes() {
 	// Initialize handlers
-	authHandler := handler.NewAuthHandler(s.client, s.authService)
-	userHandler := handler.NewUserHandler(s.client, s.authService)
-	chatHandler := handler.NewChatHandler(s.client)
-	messageHandler := handler.NewMessageHandler(s.client)
-	wsHandler := handler.NewWebSocketHandler(s.client, s.authService)
+	authHandler := handler.NewAuthHandler(s.client, s.authService, s.sessionService, s.mfaService, s.accountService, s.loginGuard)
+	mfaHandler := handler.NewMFAHandler(s.mfaService)
+	accountHandler := handler.NewAccountHandler(s.accountService, s.loginGuard.Namespace("password_reset"))
+	botHandler := handler.NewBotHandler(s.client, s.authService, s.botService)
+	oidcHandler := handler.NewOIDCHandler(s.oidcService, s.sessionService, s.mfaService, s.config.Auth.OIDC, s.config.Server.Environment == "production")
+	userHandler := handler.NewUserHandler(s.client, s.authService, s.sessionService, s.accountService, s.mfaService, s.loginGuard, time.Duration(s.config.Auth.OIDC.ReauthWindow)*time.Minute)
+	chatHandler := handler.NewChatHandler(s.client, s.events)
+	messageHandler := handler.NewMessageHandler(s.client, s.events)
+	wsHandler := handler.NewWebSocketHandler(s.client, s.authService, s.sessionService, s.ticketIssuer, s.hub, s.eventLog, s.deliveries, s.events, s.config.WebSocket)
+
+	// Close live WebSocket connections of revoked sessions
+	s.sessionService.OnRevoke(wsHandler.CloseSessions)
+
+	// Announce presence changes and record when users were last seen
+	s.hub.OnPresenceChange(wsHandler.HandlePresenceChange)
+
+	// Record which messages reached their recipients
+	s.hub.OnDelivered(wsHandler.HandleDelivered)
+
+	// Deliver chat and message changes to connected clients
+	s.events.Subscribe(wsHandler.HandleEvent)
 
 	// Health check
 	s.app.Get("/health", func(c fiber.Ctx) error {
+		// Without the broker no real-time event reaches any client
+		if !s.hub.Stats().BrokerConnected {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"status":    "unavailable",
+				"error":     "websocket broker disconnected",
+				"timestamp": time.Now().Unix(),
+				"service":   "chat-app-backend",
+			})
+		}
 		return c.JSON(fiber.Map{
 			"status":    "ok",
 			"timestamp": time.Now().Unix(),
@@ -86,6 +247,9 @@ func (s *Server) setupRoutes() {
 		})
 	})
 
+	// Verifying keys for v4.public tokens
+	s.app.Get("/.well-known/paseto-keys", authHandler.PublicKeys)
+
 	// API v1 routes
 	v1 := s.app.Group("/api/v1")
 
@@ -93,42 +257,92 @@ func (s *Server) setupRoutes() {
 	authRoutes := v1.Group("/auth")
 	authRoutes.Post("/register", authHandler.Register)
 	authRoutes.Post("/login", authHandler.Login)
+	authRoutes.Post("/login/mfa", authHandler.LoginMFA)
+	authRoutes.Post("/refresh", authHandler.Refresh)
+	authRoutes.Post("/email/verify", accountHandler.VerifyEmail)
+	authRoutes.Post("/password/forgot", accountHandler.ForgotPassword)
+	authRoutes.Post("/password/reset", accountHandler.ResetPassword)
+	authRoutes.Get("/oidc/providers", oidcHandler.ListProviders)
+	authRoutes.Get("/oidc/:provider/login", oidcHandler.Login)
+	authRoutes.Get("/oidc/:provider/callback", oidcHandler.Callback)
 
 	// Protected routes
-	authMiddleware := middleware.AuthMiddleware(s.authService)
+	authMiddleware := middleware.AuthMiddleware(s.authService, s.sessionService, s.botService)
+	userOnly := middleware.RequireUser()
 
 	// Auth protected routes
 	authRoutes.Get("/me", authMiddleware, authHandler.GetMe)
+	authRoutes.Post("/logout", authMiddleware, userOnly, authHandler.Logout)
+	authRoutes.Get("/sessions", authMiddleware, userOnly, authHandler.ListSessions)
+	authRoutes.Delete("/sessions", authMiddleware, userOnly, authHandler.RevokeOtherSessions)
+	authRoutes.Delete("/sessions/:id", authMiddleware, userOnly, authHandler.RevokeSession)
+	authRoutes.Post("/email/verification", authMiddleware, userOnly, accountHandler.SendVerificationEmail)
+	authRoutes.Post("/oidc/:provider/link", authMiddleware, userOnly, oidcHandler.Link)
+	authRoutes.Get("/identities", authMiddleware, userOnly, oidcHandler.ListIdentities)
+	authRoutes.Delete("/identities/:id", authMiddleware, userOnly, oidcHandler.UnlinkIdentity)
+
+	// Two-factor authentication routes
+	mfaRoutes := authRoutes.Group("/mfa", authMiddleware, userOnly)
+	mfaRoutes.Post("/totp/setup", mfaHandler.SetupTOTP)
+	mfaRoutes.Post("/totp/confirm", mfaHandler.ConfirmTOTP)
+	mfaRoutes.Post("/totp/disable", mfaHandler.DisableTOTP)
+	mfaRoutes.Post("/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
 
 	// User routes
 	userRoutes := v1.Group("/users", authMiddleware)
-	userRoutes.Get("/", userHandler.ListUsers)
-	userRoutes.Get("/:id", userHandler.GetUser)
-	userRoutes.Put("/:id", userHandler.UpdateUser)
-	userRoutes.Delete("/:id", userHandler.DeleteUser)
-	userRoutes.Post("/last-seen", userHandler.UpdateLastSeen)
+	userRoutes.Get("/", middleware.RequireScope(auth.ScopeUsersRead), userHandler.ListUsers)
+	userRoutes.Get("/presence", middleware.RequireScope(auth.ScopeUsersRead), wsHandler.GetPresence)
+	userRoutes.Post("/me/password", userOnly, userHandler.ChangePassword)
+	userRoutes.Get("/:id", middleware.RequireScope(auth.ScopeUsersRead), userHandler.GetUser)
+	userRoutes.Put("/:id", userOnly, userHandler.UpdateUser)
+	userRoutes.Delete("/:id", userOnly, userHandler.DeleteUser)
 
-	// Chat routes
+	// Bot management routes (owners only, bots cannot manage bots)
+	botRoutes := v1.Group("/bots", authMiddleware, userOnly)
+	botRoutes.Post("/", botHandler.CreateBot)
+	botRoutes.Get("/", botHandler.ListBots)
+	botRoutes.Delete("/:id", botHandler.DeleteBot)
+	botRoutes.Post("/:id/tokens", botHandler.CreateAPIToken)
+	botRoutes.Get("/:id/tokens", botHandler.ListAPITokens)
+	botRoutes.Delete("/:id/tokens/:tokenId", botHandler.RevokeAPIToken)
+
+	// Chat routes; bots can only be added to chats by their members
+	chatsRead := middleware.RequireScope(auth.ScopeChatsRead)
+	chatsWrite := middleware.RequireScope(auth.ScopeChatsWrite)
+	messagesRead := middleware.RequireScope(auth.ScopeMessagesRead)
+	messagesWrite := middleware.RequireScope(auth.ScopeMessagesWrite)
 	chatRoutes := v1.Group("/chats", authMiddleware)
-	chatRoutes.Post("/", chatHandler.CreateChat)
-	chatRoutes.Get("/", chatHandler.ListChats)
-	chatRoutes.Get("/:id", chatHandler.GetChat)
-	chatRoutes.Put("/:id", chatHandler.UpdateChat)
-	chatRoutes.Delete("/:id", chatHandler.DeleteChat)
-	chatRoutes.Post("/:id/members", chatHandler.AddMembers)
-	chatRoutes.Delete("/:id/members/:memberId", chatHandler.RemoveMember)
+	chatRoutes.Post("/", userOnly, chatHandler.CreateChat)
+	chatRoutes.Get("/", chatsRead, chatHandler.ListChats)
+	chatRoutes.Get("/:id", chatsRead, chatHandler.GetChat)
+	chatRoutes.Put("/:id", chatsWrite, chatHandler.UpdateChat)
+	chatRoutes.Delete("/:id", userOnly, chatHandler.DeleteChat)
+	chatRoutes.Post("/:id/members", chatsWrite, chatHandler.AddMembers)
+	chatRoutes.Delete("/:id/members/:memberId", chatsWrite, chatHandler.RemoveMember)
+	chatRoutes.Get("/:id/typing", chatsRead, wsHandler.ListTyping)
+	chatRoutes.Post("/:id/read", messagesRead, chatHandler.MarkRead)
+	chatRoutes.Get("/:id/emoji", chatsRead, chatHandler.ListEmoji)
+	chatRoutes.Post("/:id/emoji", chatsWrite, chatHandler.AddEmoji)
+	chatRoutes.Delete("/:id/emoji/:name", chatsWrite, chatHandler.RemoveEmoji)
 
 	// Message routes
 	messageRoutes := v1.Group("/messages", authMiddleware)
-	messageRoutes.Post("/", messageHandler.SendMessage)
-	messageRoutes.Get("/:id", messageHandler.GetMessage)
-	messageRoutes.Get("/chat/:chatId", messageHandler.ListMessages)
-	messageRoutes.Put("/:id", messageHandler.UpdateMessage)
-	messageRoutes.Delete("/:id", messageHandler.DeleteMessage)
+	messageRoutes.Post("/", messagesWrite, messageHandler.SendMessage)
+	messageRoutes.Get("/:id", messagesRead, messageHandler.GetMessage)
+	messageRoutes.Get("/:id/seen", messagesRead, messageHandler.GetSeenBy)
+	messageRoutes.Get("/:id/replies", messagesRead, messageHandler.ListReplies)
+	messageRoutes.Get("/:id/reactions", messagesRead, messageHandler.ListReactions)
+	messageRoutes.Post("/:id/reactions", messagesWrite, messageHandler.AddReaction)
+	messageRoutes.Delete("/:id/reactions", messagesWrite, messageHandler.RemoveReaction)
+	messageRoutes.Get("/chat/:chatId", messagesRead, messageHandler.ListMessages)
+	messageRoutes.Put("/:id", messagesWrite, messageHandler.UpdateMessage)
+	messageRoutes.Delete("/:id", messagesWrite, messageHandler.DeleteMessage)
 
-	// WebSocket route
+	// WebSocket routes
+	v1.Post("/ws/ticket", authMiddleware, userOnly, wsHandler.IssueTicket)
 	s.app.Get("/ws", wsHandler.HandleWebSocket())
 	s.app.Get("/ws/health", wsHandler.HealthCheck)
+	s.app.Get("/ws/schema", wsHandler.Schema)
 }
 
 // Start starts the HTTP server
@@ -137,13 +351,45 @@ func (s *Server) Start() error {
 	log.Printf("Starting server on %s", addr)
 	log.Printf("Environment: %s", s.config.Server.Environment)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopJobs = cancel
+	go s.pruneEvents(ctx)
+	go s.deliveries.Run(ctx)
+	go s.hub.Run(ctx)
+
 	return s.app.Listen(addr)
 }
 
+// pruneEvents deletes WebSocket events past their retention once an hour
+// until ctx is cancelled.
+func (s *Server) pruneEvents(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.eventLog.Prune(ctx)
+		if err != nil {
+			log.Printf("Failed to prune websocket events: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Pruned %d websocket events", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown() error {
 	log.Println("Shutting down server...")
 
+	if s.stopJobs != nil {
+		s.stopJobs()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -157,8 +403,21 @@ func (s *Server) Shutdown() error {
 
 // Close closes database connections and other resources
 func (s *Server) Close() error {
+	if err := s.broker.Close(); err != nil {
+		return fmt.Errorf("error closing websocket broker: %w", err)
+	}
+	if s.brokerDB != nil {
+		if err := s.brokerDB.Close(); err != nil {
+			return fmt.Errorf("error closing websocket broker connection: %w", err)
+		}
+	}
 	if err := s.client.Close(); err != nil {
 		return fmt.Errorf("error closing database connection: %w", err)
 	}
+	if s.redis != nil {
+		if err := s.redis.Close(); err != nil {
+			return fmt.Errorf("error closing redis connection: %w", err)
+		}
+	}
 	return nil
 }
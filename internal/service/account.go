@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/usertoken"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/mail"
+)
+
+var (
+	ErrInvalidAccountToken  = errors.New("invalid or expired token")
+	ErrEmailNotSet          = errors.New("user has no email address")
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+)
+
+// AccountService handles account recovery: email verification and password
+// reset through single-use links sent by email.
+type AccountService struct {
+	client          *ent.Client
+	authService     *auth.Service
+	sessionService  *SessionService
+	mailer          mail.Mailer
+	baseURL         string
+	verificationTTL time.Duration
+	resetTTL        time.Duration
+}
+
+func NewAccountService(client *ent.Client, authService *auth.Service, sessionService *SessionService, mailer mail.Mailer, cfg config.MailConfig) *AccountService {
+	return &AccountService{
+		client:          client,
+		authService:     authService,
+		sessionService:  sessionService,
+		mailer:          mailer,
+		baseURL:         strings.TrimRight(cfg.BaseURL, "/"),
+		verificationTTL: time.Duration(cfg.VerificationTokenTTL) * time.Hour,
+		resetTTL:        time.Duration(cfg.ResetTokenTTL) * time.Minute,
+	}
+}
+
+// NormalizeEmail trims and lowercases an email address so lookups are
+// case-insensitive.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// SendVerificationEmail emails the user a link to verify their current address.
+func (s *AccountService) SendVerificationEmail(ctx context.Context, userID int) error {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.Email == nil {
+		return ErrEmailNotSet
+	}
+	if u.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := s.createToken(ctx, u.ID, usertoken.PurposeEmailVerification, *u.Email, s.verificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mail.Message{
+		To:      *u.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nConfirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			u.Username, s.link("/verify-email", token), s.verificationTTL,
+		),
+	})
+}
+
+// VerifyEmail marks the address a verification token was sent to as verified.
+// The token is rejected if the user changed their email since.
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) error {
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	t, err := s.useToken(ctx, tx, usertoken.PurposeEmailVerification, token)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	affected, err := tx.User.Update().
+		Where(
+			user.ID(t.Edges.User.ID),
+			user.Email(t.Email),
+		).
+		SetEmailVerifiedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	if affected == 0 {
+		_ = tx.Rollback()
+		return ErrInvalidAccountToken
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RequestPasswordReset emails a reset link if an account uses the address.
+// It reports success either way so callers cannot probe for accounts; the
+// email is sent in the background for the same reason.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = NormalizeEmail(email)
+
+	u, err := s.client.User.Query().
+		Where(user.Email(email)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	token, err := s.createToken(ctx, u.ID, usertoken.PurposePasswordReset, email, s.resetTTL)
+	if err != nil {
+		return err
+	}
+
+	msg := mail.Message{
+		To:      email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your account. If it was you, open the link below:\n\n%s\n\nThe link expires in %s. If you did not ask for this, you can ignore this email.\n",
+			u.Username, s.link("/reset-password", token), s.resetTTL,
+		),
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			log.Printf("Error sending password reset email to user %d: %v", u.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token, invalidates the
+// user's other reset tokens and revokes all of their sessions. Passwords that
+// break the policy are returned as *auth.PasswordPolicyError.
+func (s *AccountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	t, err := s.useToken(ctx, tx, usertoken.PurposePasswordReset, token)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	userID := t.Edges.User.ID
+
+	u, err := tx.User.Get(ctx, userID)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// A rejected password rolls back, so the token can be used again
+	hashedPassword, err := s.authService.HashNewPassword(newPassword, u.Username)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	update := tx.User.UpdateOneID(userID).SetPassword(hashedPassword)
+	// Following the link proves the user controls the address
+	if u.Email != nil && *u.Email == t.Email && u.EmailVerifiedAt == nil {
+		update.SetEmailVerifiedAt(time.Now())
+	}
+	if err := update.Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	_, err = tx.UserToken.Update().
+		Where(
+			usertoken.HasUserWith(user.ID(userID)),
+			usertoken.PurposeEQ(usertoken.PurposePasswordReset),
+			usertoken.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.sessionService.RevokeOtherSessions(ctx, userID, 0)
+}
+
+func (s *AccountService) createToken(ctx context.Context, userID int, purpose usertoken.Purpose, email string, ttl time.Duration) (string, error) {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.client.UserToken.Create().
+		SetUserID(userID).
+		SetPurpose(purpose).
+		SetTokenHash(auth.HashOpaqueToken(token)).
+		SetEmail(email).
+		SetExpiresAt(time.Now().Add(ttl)).
+		Exec(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, nil
+}
+
+// useToken marks a valid token as used. Only the request that flips used_at
+// wins, so a token can never be used twice.
+func (s *AccountService) useToken(ctx context.Context, tx *ent.Tx, purpose usertoken.Purpose, token string) (*ent.UserToken, error) {
+	hash := auth.HashOpaqueToken(token)
+	now := time.Now()
+
+	affected, err := tx.UserToken.Update().
+		Where(
+			usertoken.TokenHash(hash),
+			usertoken.PurposeEQ(purpose),
+			usertoken.UsedAtIsNil(),
+			usertoken.ExpiresAtGT(now),
+		).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use token: %w", err)
+	}
+	if affected == 0 {
+		return nil, ErrInvalidAccountToken
+	}
+
+	t, err := tx.UserToken.Query().
+		Where(usertoken.TokenHash(hash)).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return t, nil
+}
+
+func (s *AccountService) link(path, token string) string {
+	return s.baseURL + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+)
+
+// Audit actions
+const (
+	AuditLoginLockout    = "login.lockout"
+	AuditPasswordChanged = "user.password_changed"
+	AuditAccountDeleted  = "user.deleted"
+	AuditBotCreated      = "bot.created"
+	AuditBotDeleted      = "bot.deleted"
+	AuditAPITokenCreated = "api_token.created"
+	AuditAPITokenRevoked = "api_token.revoked"
+)
+
+// AuditEntry describes a security relevant event.
+type AuditEntry struct {
+	Action    string
+	UserID    int // 0 when the event is not tied to a known user
+	IPAddress string
+	Details   map[string]interface{}
+}
+
+type AuditService struct {
+	client *ent.Client
+}
+
+func NewAuditService(client *ent.Client) *AuditService {
+	return &AuditService{client: client}
+}
+
+func (s *AuditService) Record(ctx context.Context, entry AuditEntry) error {
+	create := s.client.AuditLog.Create().
+		SetAction(entry.Action).
+		SetIPAddress(truncate(entry.IPAddress, 64))
+
+	if entry.UserID != 0 {
+		create.SetUserID(entry.UserID)
+	}
+	if entry.Details != nil {
+		create.SetDetails(entry.Details)
+	}
+
+	if err := create.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/apitoken"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+)
+
+var (
+	ErrBotNotFound      = errors.New("bot not found")
+	ErrAPITokenNotFound = errors.New("api token not found")
+	ErrInvalidAPIToken  = errors.New("invalid api token")
+	ErrInvalidScope     = errors.New("invalid scope")
+)
+
+// apiTokenPrefix marks API tokens so they are recognizable, e.g. by secret scanners.
+const apiTokenPrefix = "cab_"
+
+// botPassword is stored as the password hash of bots, so bots can never log
+// in with a password.
+const botPassword = auth.UnusablePasswordPrefix + "bot"
+
+// lastUsedPrecision limits how often last_used_at of a token is written.
+const lastUsedPrecision = time.Minute
+
+type BotService struct {
+	client *ent.Client
+}
+
+func NewBotService(client *ent.Client) *BotService {
+	return &BotService{client: client}
+}
+
+// CreateBot creates a bot account owned by the user.
+func (s *BotService) CreateBot(ctx context.Context, ownerID int, username, displayName string) (*ent.User, error) {
+	bot, err := s.client.User.Create().
+		SetType(user.TypeBot).
+		SetUsername(username).
+		SetPassword(botPassword).
+		SetDisplayName(displayName).
+		SetOwnerID(ownerID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bot: %w", err)
+	}
+
+	return bot, nil
+}
+
+// ListBots returns the bots owned by the user.
+func (s *BotService) ListBots(ctx context.Context, ownerID int) ([]*ent.User, error) {
+	bots, err := s.client.User.Query().
+		Where(
+			user.TypeEQ(user.TypeBot),
+			user.HasOwnerWith(user.ID(ownerID)),
+		).
+		Order(ent.Asc(user.FieldUsername)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bots: %w", err)
+	}
+
+	return bots, nil
+}
+
+// GetOwnedBot returns a bot after checking that it belongs to the owner.
+func (s *BotService) GetOwnedBot(ctx context.Context, ownerID, botID int) (*ent.User, error) {
+	bot, err := s.client.User.Query().
+		Where(
+			user.ID(botID),
+			user.TypeEQ(user.TypeBot),
+			user.HasOwnerWith(user.ID(ownerID)),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrBotNotFound
+		}
+		return nil, fmt.Errorf("failed to get bot: %w", err)
+	}
+
+	return bot, nil
+}
+
+// DeleteBot deletes a bot of the owner together with its API tokens.
+func (s *BotService) DeleteBot(ctx context.Context, ownerID, botID int) error {
+	if _, err := s.GetOwnedBot(ctx, ownerID, botID); err != nil {
+		return err
+	}
+
+	if err := s.client.User.DeleteOneID(botID).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete bot: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAPIToken issues a token for a bot of the owner. The plain token is
+// only returned here; nil expiresAt means the token does not expire.
+func (s *BotService) CreateAPIToken(ctx context.Context, ownerID, botID int, name string, scopes []string, expiresAt *time.Time) (string, *ent.APIToken, error) {
+	for _, scope := range scopes {
+		if !auth.IsValidScope(scope) {
+			return "", nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	if _, err := s.GetOwnedBot(ctx, ownerID, botID); err != nil {
+		return "", nil, err
+	}
+
+	secret, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	token := apiTokenPrefix + secret
+
+	apiToken, err := s.client.APIToken.Create().
+		SetUserID(botID).
+		SetName(name).
+		SetTokenHash(auth.HashOpaqueToken(token)).
+		SetPrefix(token[:len(apiTokenPrefix)+8]).
+		SetScopes(scopes).
+		SetNillableExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create api token: %w", err)
+	}
+
+	return token, apiToken, nil
+}
+
+// ListAPITokens returns the tokens of a bot of the owner, newest first.
+func (s *BotService) ListAPITokens(ctx context.Context, ownerID, botID int) ([]*ent.APIToken, error) {
+	if _, err := s.GetOwnedBot(ctx, ownerID, botID); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.client.APIToken.Query().
+		Where(apitoken.HasUserWith(user.ID(botID))).
+		Order(ent.Desc(apitoken.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes a token of a bot of the owner.
+func (s *BotService) RevokeAPIToken(ctx context.Context, ownerID, botID, tokenID int) error {
+	if _, err := s.GetOwnedBot(ctx, ownerID, botID); err != nil {
+		return err
+	}
+
+	affected, err := s.client.APIToken.Update().
+		Where(
+			apitoken.ID(tokenID),
+			apitoken.HasUserWith(user.ID(botID)),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+	if affected == 0 {
+		return ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+// Authenticate returns the API token, with its bot loaded, for a presented
+// token. Revoked and expired tokens are rejected with ErrInvalidAPIToken.
+func (s *BotService) Authenticate(ctx context.Context, token string) (*ent.APIToken, error) {
+	now := time.Now()
+
+	apiToken, err := s.client.APIToken.Query().
+		Where(
+			apitoken.TokenHash(auth.HashOpaqueToken(token)),
+			apitoken.RevokedAtIsNil(),
+			apitoken.Or(apitoken.ExpiresAtIsNil(), apitoken.ExpiresAtGT(now)),
+			apitoken.HasUserWith(user.TypeEQ(user.TypeBot)),
+		).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidAPIToken
+		}
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) >= lastUsedPrecision {
+		err := s.client.APIToken.UpdateOneID(apiToken.ID).
+			SetLastUsedAt(now).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update api token: %w", err)
+		}
+	}
+
+	return apiToken, nil
+}
@@ -2,20 +2,38 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"entgo.io/ent/dialect/sql"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
 )
 
+var (
+	ErrMessageNotInChat = errors.New("message does not belong to the chat")
+	ErrEmojiExists      = errors.New("chat already has an emoji with this name")
+	ErrEmojiNotFound    = errors.New("emoji not found")
+	ErrInvalidEmojiName = errors.New("invalid emoji name")
+)
+
 type ChatService struct {
 	client *ent.Client
+	events *event.Bus
 }
 
-func NewChatService(client *ent.Client) *ChatService {
-	return &ChatService{client: client}
+func NewChatService(client *ent.Client, events *event.Bus) *ChatService {
+	return &ChatService{
+		client: client,
+		events: events,
+	}
 }
 
 func (s *ChatService) CreateChat(ctx context.Context, name string, isGroup bool, creatorID int, memberIDs []int) (*ent.Chat, error) {
@@ -66,12 +84,27 @@ func (s *ChatService) CreateChat(ctx context.Context, name string, isGroup bool,
 		}
 	}
 
+	s.publishChat(ctx, event.ChatCreated, newChat.ID)
+
 	return newChat, nil
 }
 
-func (s *ChatService) ListUserChats(ctx context.Context, userID, limit, offset int, withMembers bool) ([]*ent.Chat, error) {
+// ChatSummary is a chat in a user's chat list.
+type ChatSummary struct {
+	Chat *ent.Chat
+	// LastMessage is the newest message with its sender, or nil in an empty chat
+	LastMessage *ent.Message
+	// UnreadCount is how many messages of other members are past the user's
+	// read cursor
+	UnreadCount int
+}
+
+// ListUserChats returns the chats of a user, the most recently active first.
+// A chat is active when a message is sent to it, or when it is created. The
+// last message and the unread count of every chat are computed by the same
+// query, so the list costs a fixed number of queries however long it is.
+func (s *ChatService) ListUserChats(ctx context.Context, userID, limit, offset int, withMembers bool) ([]ChatSummary, error) {
 	q := s.client.Chat.Query().
-		Where(chat.HasMembersWith(chatmember.HasUserWith(user.ID(userID)))).
 		WithCreator()
 
 	if withMembers {
@@ -82,14 +115,120 @@ func (s *ChatService) ListUserChats(ctx context.Context, userID, limit, offset i
 
 	chats, err := q.Limit(limit).
 		Offset(offset).
-		Order(ent.Desc(chat.FieldUpdatedAt)).
+		Modify(func(sel *sql.Selector) {
+			summarizeChats(sel, userID)
+		}).
 		All(ctx)
-
 	if err != nil {
 		return nil, fmt.Errorf("failed to list chats: %w", err)
 	}
 
-	return chats, nil
+	summaries := make([]ChatSummary, len(chats))
+	lastMessageOf := make(map[int]*ChatSummary)
+	for i, c := range chats {
+		summaries[i] = ChatSummary{
+			Chat:        c,
+			UnreadCount: intValue(c, "unread_count"),
+		}
+		if id := intValue(c, "last_message_id"); id != 0 {
+			lastMessageOf[id] = &summaries[i]
+		}
+	}
+	if len(lastMessageOf) == 0 {
+		return summaries, nil
+	}
+
+	lastMessageIDs := make([]int, 0, len(lastMessageOf))
+	for id := range lastMessageOf {
+		lastMessageIDs = append(lastMessageIDs, id)
+	}
+	lastMessages, err := s.client.Message.Query().
+		Where(message.IDIn(lastMessageIDs...)).
+		WithSender().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last messages: %w", err)
+	}
+	for _, msg := range lastMessages {
+		lastMessageOf[msg.ID].LastMessage = msg
+	}
+
+	return summaries, nil
+}
+
+// summarizeChats limits a chat query to the chats of a user, selects the ID
+// of each chat's last message as last_message_id and the user's unread count
+// as unread_count, and orders the chats by their last activity.
+func summarizeChats(sel *sql.Selector, userID int) {
+	members := sql.Table(chatmember.Table)
+	sel.Join(members).
+		On(sel.C(chat.FieldID), members.C(chatmember.ChatColumn)).
+		Where(sql.EQ(members.C(chatmember.UserColumn), userID))
+
+	messages := sql.Table(message.Table)
+	inChat := sql.ColumnsEQ(messages.C(message.ChatColumn), sel.C(chat.FieldID))
+	lastMessage := sql.Dialect(sel.Dialect()).
+		Select(sql.Max(messages.C(message.FieldID))).
+		From(messages).
+		Where(inChat)
+	lastActivity := sql.Dialect(sel.Dialect()).
+		Select(sql.Max(messages.C(message.FieldCreatedAt))).
+		From(messages).
+		Where(inChat)
+	unread := sql.Dialect(sel.Dialect()).
+		Select(sql.Count("*")).
+		From(messages).
+		Where(sql.And(
+			inChat,
+			sql.NEQ(messages.C(message.SenderColumn), userID),
+			sql.Or(
+				sql.IsNull(members.C(chatmember.FieldLastReadMessageID)),
+				sql.ColumnsGT(messages.C(message.FieldID), members.C(chatmember.FieldLastReadMessageID)),
+			),
+		))
+
+	sel.AppendSelectExprAs(lastMessage, "last_message_id").
+		AppendSelectExprAs(unread, "unread_count").
+		OrderExprFunc(func(b *sql.Builder) {
+			b.WriteString("COALESCE(").
+				Wrap(func(b *sql.Builder) { b.Join(lastActivity) }).
+				Comma().
+				WriteString(sel.C(chat.FieldCreatedAt)).
+				WriteString(") DESC").
+				Comma().
+				WriteString(sel.C(chat.FieldID)).
+				WriteString(" DESC")
+		})
+}
+
+// valuer is an entity with columns selected by a modifier.
+type valuer interface {
+	Value(name string) (ent.Value, error)
+}
+
+// intValue reads an integer column selected by a modifier. Drivers return
+// them as int64, and NULL as nil.
+func intValue(e valuer, name string) int {
+	v, err := e.Value(name)
+	if err != nil {
+		return 0
+	}
+	n, _ := v.(int64)
+	return int(n)
+}
+
+// timeValue reads a timestamp column selected by a modifier, or nil if it
+// is NULL.
+func timeValue(e valuer, name string) *time.Time {
+	v, err := e.Value(name)
+	if err != nil {
+		return nil
+	}
+	t, ok := v.(time.Time)
+	if !ok {
+		return nil
+	}
+	return &t
 }
 
 func (s *ChatService) GetChatByID(ctx context.Context, chatID int) (*ent.Chat, error) {
@@ -124,6 +263,48 @@ func (s *ChatService) IsUserMemberOfChat(ctx context.Context, chatID, userID int
 	return isMember, nil
 }
 
+// ListContactIDs returns the IDs of the other users the user shares a chat
+// with.
+func (s *ChatService) ListContactIDs(ctx context.Context, userID int) ([]int, error) {
+	ids, err := s.client.User.Query().
+		Where(
+			user.IDNEQ(userID),
+			user.HasChatMembersWith(chatmember.HasChatWith(
+				chat.HasMembersWith(chatmember.HasUserWith(user.ID(userID))),
+			)),
+		).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	return ids, nil
+}
+
+// FilterContactIDs returns those of the given users who share a chat with the
+// user.
+func (s *ChatService) FilterContactIDs(ctx context.Context, userID int, ids []int) ([]int, error) {
+	contactIDs, err := s.client.User.Query().
+		Where(
+			user.IDIn(ids...),
+			user.IDNEQ(userID),
+			user.HasChatMembersWith(chatmember.HasChatWith(
+				chat.HasMembersWith(chatmember.HasUserWith(user.ID(userID))),
+			)),
+		).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter contacts: %w", err)
+	}
+
+	return contactIDs, nil
+}
+
+// ListMemberIDs returns the IDs of the members of a chat.
+func (s *ChatService) ListMemberIDs(ctx context.Context, chatID int) ([]int, error) {
+	return chatMemberIDs(ctx, s.client, chatID)
+}
+
 func (s *ChatService) IsUserAdminOfChat(ctx context.Context, chatID, userID int) (bool, error) {
 	member, err := s.client.ChatMember.Query().
 		Where(
@@ -152,11 +333,21 @@ func (s *ChatService) UpdateChatName(ctx context.Context, chatID int, name strin
 		return nil, fmt.Errorf("failed to update chat: %w", err)
 	}
 
+	if updated := s.publishChat(ctx, event.ChatUpdated, chatID); updated != nil {
+		return updated, nil
+	}
+
 	return chatEntity, nil
 }
 
 func (s *ChatService) DeleteChat(ctx context.Context, chatID int) error {
-	err := s.client.Chat.DeleteOneID(chatID).Exec(ctx)
+	// The members are gone with the chat, so collect them first
+	memberIDs, err := chatMemberIDs(ctx, s.client, chatID)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Chat.DeleteOneID(chatID).Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return fmt.Errorf("chat not found")
@@ -164,6 +355,12 @@ func (s *ChatService) DeleteChat(ctx context.Context, chatID int) error {
 		return fmt.Errorf("failed to delete chat: %w", err)
 	}
 
+	s.events.Publish(ctx, event.Event{
+		Type:       event.ChatDeleted,
+		ChatID:     chatID,
+		Recipients: memberIDs,
+	})
+
 	return nil
 }
 
@@ -188,6 +385,7 @@ func (s *ChatService) GetChatCreator(ctx context.Context, chatID int) (*ent.User
 }
 
 func (s *ChatService) AddMembers(ctx context.Context, chatID int, memberIDs []int) error {
+	var added []int
 	for _, memberID := range memberIDs {
 		// Check if already a member
 		exists, _ := s.client.ChatMember.Query().
@@ -218,14 +416,38 @@ func (s *ChatService) AddMembers(ctx context.Context, chatID int, memberIDs []in
 			// Continue even if adding fails
 			continue
 		}
+		added = append(added, memberID)
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	// Existing members learn about the new ones, new members about the chat
+	recipients, err := chatMemberIDs(ctx, s.client, chatID)
+	if err != nil {
+		log.Printf("Error publishing %s event of chat %d: %v", event.MemberAdded, chatID, err)
+		return nil
 	}
+	s.events.Publish(ctx, event.Event{
+		Type:       event.MemberAdded,
+		ChatID:     chatID,
+		Recipients: recipients,
+		MemberIDs:  added,
+	})
 
 	return nil
 }
 
 func (s *ChatService) RemoveMember(ctx context.Context, chatID, memberID int) error {
+	// The removed member is told as well
+	recipients, err := chatMemberIDs(ctx, s.client, chatID)
+	if err != nil {
+		return err
+	}
+
 	// Delete member
-	_, err := s.client.ChatMember.Delete().
+	removed, err := s.client.ChatMember.Delete().
 		Where(
 			chatmember.HasChatWith(chat.ID(chatID)),
 			chatmember.HasUserWith(user.ID(memberID)),
@@ -235,5 +457,173 @@ func (s *ChatService) RemoveMember(ctx context.Context, chatID, memberID int) er
 		return fmt.Errorf("failed to remove member: %w", err)
 	}
 
+	if removed > 0 {
+		s.events.Publish(ctx, event.Event{
+			Type:       event.MemberRemoved,
+			ChatID:     chatID,
+			Recipients: recipients,
+			MemberIDs:  []int{memberID},
+		})
+	}
+
 	return nil
 }
+
+// MarkRead moves the read cursor of a member up to a message of the chat and
+// returns the member. The cursor never moves back. The other members are told
+// unless the user hides read receipts; the user's own devices always are.
+func (s *ChatService) MarkRead(ctx context.Context, chatID, userID, messageID int) (*ent.ChatMember, error) {
+	inChat, err := s.client.Message.Query().
+		Where(
+			message.ID(messageID),
+			message.HasChatWith(chat.ID(chatID)),
+		).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check message: %w", err)
+	}
+	if !inChat {
+		return nil, ErrMessageNotInChat
+	}
+
+	isMember := chatmember.And(
+		chatmember.HasChatWith(chat.ID(chatID)),
+		chatmember.HasUserWith(user.ID(userID)),
+	)
+	advanced, err := s.client.ChatMember.Update().
+		Where(
+			isMember,
+			chatmember.Or(
+				chatmember.LastReadMessageIDIsNil(),
+				chatmember.LastReadMessageIDLT(messageID),
+			),
+		).
+		SetLastReadMessageID(messageID).
+		SetLastReadAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update read cursor: %w", err)
+	}
+
+	member, err := s.client.ChatMember.Query().
+		Where(isMember).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("member not found")
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	if advanced == 0 {
+		return member, nil
+	}
+
+	recipients := []int{userID}
+	if !member.Edges.User.HideReadReceipts {
+		recipients, err = chatMemberIDs(ctx, s.client, chatID)
+		if err != nil {
+			log.Printf("Error publishing %s event of chat %d: %v", event.Read, chatID, err)
+			return member, nil
+		}
+	}
+	s.events.Publish(ctx, event.Event{
+		Type:       event.Read,
+		ChatID:     chatID,
+		Recipients: recipients,
+		Member:     member,
+	})
+
+	return member, nil
+}
+
+// ListEmoji returns the custom emoji of a chat ordered by name.
+func (s *ChatService) ListEmoji(ctx context.Context, chatID int) ([]*ent.ChatEmoji, error) {
+	emoji, err := s.client.ChatEmoji.Query().
+		Where(chatemoji.HasChatWith(chat.ID(chatID))).
+		Order(ent.Asc(chatemoji.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emoji: %w", err)
+	}
+
+	return emoji, nil
+}
+
+// AddEmoji adds a custom emoji to a chat. Its name consists of 2 to 32
+// lowercase letters, digits and underscores.
+func (s *ChatService) AddEmoji(ctx context.Context, chatID int, name, imageURL string) (*ent.ChatEmoji, error) {
+	created, err := s.client.ChatEmoji.Create().
+		SetChatID(chatID).
+		SetName(name).
+		SetImageURL(imageURL).
+		Save(ctx)
+	if err != nil {
+		switch {
+		case ent.IsValidationError(err):
+			return nil, ErrInvalidEmojiName
+		case ent.IsConstraintError(err):
+			return nil, ErrEmojiExists
+		}
+		return nil, fmt.Errorf("failed to add emoji: %w", err)
+	}
+
+	return created, nil
+}
+
+// RemoveEmoji removes a custom emoji from a chat. Reactions with it are kept.
+func (s *ChatService) RemoveEmoji(ctx context.Context, chatID int, name string) error {
+	removed, err := s.client.ChatEmoji.Delete().
+		Where(
+			chatemoji.HasChatWith(chat.ID(chatID)),
+			chatemoji.Name(name),
+		).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to remove emoji: %w", err)
+	}
+	if removed == 0 {
+		return ErrEmojiNotFound
+	}
+
+	return nil
+}
+
+// publishChat publishes a chat event to the members of the chat and returns
+// the chat with its creator and members, or nil if it could not be loaded.
+func (s *ChatService) publishChat(ctx context.Context, eventType string, chatID int) *ent.Chat {
+	chatEntity, err := s.GetChatByID(ctx, chatID)
+	if err != nil {
+		log.Printf("Error publishing %s event of chat %d: %v", eventType, chatID, err)
+		return nil
+	}
+
+	recipients := make([]int, 0, len(chatEntity.Edges.Members))
+	for _, member := range chatEntity.Edges.Members {
+		if member.Edges.User != nil {
+			recipients = append(recipients, member.Edges.User.ID)
+		}
+	}
+
+	s.events.Publish(ctx, event.Event{
+		Type:       eventType,
+		ChatID:     chatID,
+		Recipients: recipients,
+		Chat:       chatEntity,
+	})
+
+	return chatEntity
+}
+
+// chatMemberIDs returns the IDs of the members of a chat.
+func chatMemberIDs(ctx context.Context, client *ent.Client, chatID int) ([]int, error) {
+	ids, err := client.User.Query().
+		Where(user.HasChatMembersWith(chatmember.HasChatWith(chat.ID(chatID)))).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat members: %w", err)
+	}
+
+	return ids, nil
+}
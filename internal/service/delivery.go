@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// deliveryQueueSize bounds the receipts waiting to be recorded
+	deliveryQueueSize = 10000
+	// deliveryBatchSize is the most receipts recorded at once
+	deliveryBatchSize = 500
+	// deliveryFlushInterval is how long a receipt waits for its batch to fill
+	deliveryFlushInterval = time.Second
+)
+
+// DeliveryQueue collects the messages written to connections of their
+// recipients and records them in batches, so a busy connection does not cost
+// queries per frame and senders get one event per batch.
+type DeliveryQueue struct {
+	messages *MessageService
+	receipts chan Receipt
+}
+
+func NewDeliveryQueue(messages *MessageService) *DeliveryQueue {
+	return &DeliveryQueue{
+		messages: messages,
+		receipts: make(chan Receipt, deliveryQueueSize),
+	}
+}
+
+// Add queues a receipt without blocking. When the queue is full the receipt
+// is dropped and false returned; the delivery is then recorded when the
+// recipient lists the message.
+func (q *DeliveryQueue) Add(r Receipt) bool {
+	select {
+	case q.receipts <- r:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run records the queued receipts whenever a batch is full or has waited for
+// deliveryFlushInterval, until ctx is cancelled. The receipts still queued
+// then are recorded before it returns.
+func (q *DeliveryQueue) Run(ctx context.Context) {
+	ticker := time.NewTicker(deliveryFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Receipt, 0, deliveryBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := q.messages.MarkDelivered(context.Background(), batch); err != nil {
+			log.Printf("Error recording %d message deliveries: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case r := <-q.receipts:
+			batch = append(batch, r)
+			if len(batch) == deliveryBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for {
+				select {
+				case r := <-q.receipts:
+					batch = append(batch, r)
+					if len(batch) == deliveryBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
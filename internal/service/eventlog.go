@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/userevent"
+)
+
+// Replay is what a reconnecting client missed since the last event it saw.
+type Replay struct {
+	Events []*ent.UserEvent
+	// ResyncRequired is set when some of the missed events were pruned, or
+	// there are too many to replay. The client has to reload its state.
+	ResyncRequired bool
+	LatestSeq      int64
+}
+
+// seqLockStripes is the number of locks the event sequences of the users are
+// spread over.
+const seqLockStripes = 64
+
+// EventLogService numbers the real-time events of every user and keeps them
+// for a while so they can be replayed.
+type EventLogService struct {
+	client      *ent.Client
+	retention   time.Duration
+	replayLimit int
+
+	// seqLocks serialize the events of a user on this instance from taking
+	// the sequence number until delivery, so they are delivered in order
+	seqLocks [seqLockStripes]sync.Mutex
+}
+
+func NewEventLogService(client *ent.Client, retention time.Duration, replayLimit int) *EventLogService {
+	return &EventLogService{
+		client:      client,
+		retention:   retention,
+		replayLimit: replayLimit,
+	}
+}
+
+// Record logs an event for each of the users with the next number of their
+// sequence and, once committed, passes the logged events by user ID to
+// deliver. Events of a user recorded on this instance are delivered in the
+// order of their numbers. Nothing is delivered if logging fails, so a number
+// is never announced for an event that does not exist. Users that no longer
+// exist are skipped.
+func (s *EventLogService) Record(ctx context.Context, userIDs []int, eventType string, payload interface{}, deliver func(events map[int]*ent.UserEvent)) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	ids := uniqueSorted(userIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	unlock := s.lockSeqs(ids)
+	defer unlock()
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	seqs, err := allocateSeqs(ctx, tx, ids)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	builders := make([]*ent.UserEventCreate, 0, len(seqs))
+	owners := make([]int, 0, len(seqs))
+	for _, id := range ids {
+		seq, ok := seqs[id]
+		if !ok {
+			continue
+		}
+		builders = append(builders, tx.UserEvent.Create().
+			SetUserID(id).
+			SetSeq(seq).
+			SetType(eventType).
+			SetPayload(data))
+		owners = append(owners, id)
+	}
+
+	events, err := tx.UserEvent.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to log events: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	byUser := make(map[int]*ent.UserEvent, len(events))
+	for i, event := range events {
+		byUser[owners[i]] = event
+	}
+	if len(byUser) > 0 {
+		deliver(byUser)
+	}
+	return nil
+}
+
+// lockSeqs takes the locks of the users' sequences in a stable order, so
+// concurrent events cannot deadlock, and returns the function releasing them.
+func (s *EventLogService) lockSeqs(ids []int) func() {
+	stripes := make([]int, 0, len(ids))
+	for _, id := range ids {
+		stripes = append(stripes, id%seqLockStripes)
+	}
+	stripes = uniqueSorted(stripes)
+
+	for _, stripe := range stripes {
+		s.seqLocks[stripe].Lock()
+	}
+	return func() {
+		for _, stripe := range stripes {
+			s.seqLocks[stripe].Unlock()
+		}
+	}
+}
+
+// allocateSeqs takes the next number of the event sequence of every user and
+// returns them by user ID. The user rows are locked in a stable order first so
+// concurrent events cannot deadlock. The counters are bumped with a single
+// statement that leaves updated_at alone.
+func allocateSeqs(ctx context.Context, tx *ent.Tx, ids []int) (map[int]int64, error) {
+	var d string
+	locked, err := tx.User.Query().
+		Where(user.IDIn(ids...)).
+		Order(ent.Asc(user.FieldID)).
+		Select(user.FieldID).
+		Modify(func(s *sql.Selector) {
+			d = s.Dialect()
+			// SQLite locks the whole database instead
+			if d != dialect.SQLite {
+				s.ForUpdate()
+			}
+		}).
+		Ints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock event sequences: %w", err)
+	}
+	if len(locked) == 0 {
+		return nil, nil
+	}
+
+	query, args := sql.Dialect(d).
+		Update(user.Table).
+		Add(user.FieldEventSeq, 1).
+		Where(sql.InInts(user.FieldID, locked...)).
+		Returning(user.FieldID, user.FieldEventSeq).
+		Query()
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate event sequence: %w", err)
+	}
+	defer rows.Close()
+
+	seqs := make(map[int]int64, len(locked))
+	for rows.Next() {
+		var id int
+		var seq int64
+		if err := rows.Scan(&id, &seq); err != nil {
+			return nil, fmt.Errorf("failed to allocate event sequence: %w", err)
+		}
+		seqs[id] = seq
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to allocate event sequence: %w", err)
+	}
+	return seqs, nil
+}
+
+// Since returns the events of the user with a sequence number above since,
+// in order.
+func (s *EventLogService) Since(ctx context.Context, userID int, since int64) (*Replay, error) {
+	u, err := s.client.User.Query().
+		Where(user.ID(userID)).
+		Select(user.FieldEventSeq).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event sequence: %w", err)
+	}
+
+	replay := &Replay{LatestSeq: u.EventSeq}
+	if since == u.EventSeq {
+		return replay, nil
+	}
+	if since < 0 || since > u.EventSeq {
+		// The client saw a sequence this server never handed out
+		replay.ResyncRequired = true
+		return replay, nil
+	}
+
+	events, err := s.client.UserEvent.Query().
+		Where(
+			userevent.HasUserWith(user.ID(userID)),
+			userevent.SeqGT(since),
+		).
+		Order(ent.Asc(userevent.FieldSeq)).
+		Limit(s.replayLimit + 1).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	// The event right after since was pruned, or there are too many to replay
+	if len(events) == 0 || events[0].Seq != since+1 || len(events) > s.replayLimit {
+		replay.ResyncRequired = true
+		return replay, nil
+	}
+
+	replay.Events = events
+	return replay, nil
+}
+
+// Prune deletes events older than the retention period.
+func (s *EventLogService) Prune(ctx context.Context) (int, error) {
+	deleted, err := s.client.UserEvent.Delete().
+		Where(userevent.CreatedAtLT(time.Now().Add(-s.retention))).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune events: %w", err)
+	}
+	return deleted, nil
+}
+
+func uniqueSorted(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Ints(unique)
+	return unique
+}
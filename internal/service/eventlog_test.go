@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+)
+
+func TestEventLogRecordNumbersEventsPerUser(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	authService := newTestAuthService(t)
+	alice := createTestUser(t, client, authService, "alice", "")
+	bob := createTestUser(t, client, authService, "bob", "")
+	s := NewEventLogService(client, time.Hour, 100)
+
+	record := func(userIDs ...int) map[int]int64 {
+		t.Helper()
+		var seqs map[int]int64
+		err := s.Record(ctx, userIDs, "system", map[string]string{"message": "hi"}, func(events map[int]*ent.UserEvent) {
+			seqs = make(map[int]int64, len(events))
+			for userID, event := range events {
+				seqs[userID] = event.Seq
+			}
+		})
+		if err != nil {
+			t.Fatalf("record: %v", err)
+		}
+		return seqs
+	}
+
+	record(alice.ID)
+	// Unknown users are skipped, duplicates count once
+	seqs := record(alice.ID, bob.ID, bob.ID, 999)
+	if len(seqs) != 2 || seqs[alice.ID] != 2 || seqs[bob.ID] != 1 {
+		t.Fatalf("seqs = %v, want alice 2 and bob 1", seqs)
+	}
+
+	replay, err := s.Since(ctx, alice.ID, 0)
+	if err != nil {
+		t.Fatalf("since: %v", err)
+	}
+	if replay.LatestSeq != 2 || len(replay.Events) != 2 || replay.Events[1].Seq != 2 {
+		t.Fatalf("replay = %+v, want events 1 and 2", replay)
+	}
+
+	reloaded, err := client.User.Get(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if !reloaded.UpdatedAt.Equal(alice.UpdatedAt) {
+		t.Fatalf("updated_at changed from %v to %v", alice.UpdatedAt, reloaded.UpdatedAt)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestClient opens an empty in-memory database with the schema applied.
+func newTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	client, err := ent.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", name))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return client
+}
+
+// newTestAuthService returns an auth service with a fixed local key and the
+// default settings.
+func newTestAuthService(t *testing.T) *auth.Service {
+	t.Helper()
+
+	cfg := config.DefaultConfig.Auth
+	cfg.ActiveKeyID = "test"
+	cfg.Keys = []config.PasetoKeyConfig{{
+		ID:  "test",
+		Key: strings.Repeat("ab", 32),
+	}}
+
+	authService, err := auth.NewAuthService(cfg)
+	if err != nil {
+		t.Fatalf("create auth service: %v", err)
+	}
+	return authService
+}
+
+// createTestUser creates a user with the given password, or with no usable
+// password when it is empty.
+func createTestUser(t *testing.T, client *ent.Client, authService *auth.Service, username, password string) *ent.User {
+	t.Helper()
+
+	hash := ssoPassword
+	if password != "" {
+		var err error
+		if hash, err = authService.HashPassword(password); err != nil {
+			t.Fatalf("hash password: %v", err)
+		}
+	}
+
+	u, err := client.User.Create().
+		SetUsername(username).
+		SetEmail(username + "@example.com").
+		SetPassword(hash).
+		Save(context.Background())
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return u
+}
@@ -3,32 +3,76 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
+	"time"
 
+	"entgo.io/ent/dialect/sql"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatmember"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/messagedelivery"
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
 )
 
+// DeliveryCounts is how many recipients of a message, the members of its chat
+// other than the sender, received and read it. Reading a message implies
+// receiving it. Members who hide their read receipts never count as readers.
+type DeliveryCounts struct {
+	Recipients int
+	Delivered  int
+	Read       int
+}
+
 type MessageService struct {
 	client *ent.Client
+	events *event.Bus
 }
 
-func NewMessageService(client *ent.Client) *MessageService {
-	return &MessageService{client: client}
+func NewMessageService(client *ent.Client, events *event.Bus) *MessageService {
+	return &MessageService{
+		client: client,
+		events: events,
+	}
 }
 
-func (s *MessageService) SendMessage(ctx context.Context, chatID, senderID int, content string) (*ent.Message, error) {
-	// Create message
-	newMessage, err := s.client.Message.Create().
+// SendMessage creates a message. A non-zero replyToID makes it a reply to
+// that message, which must be in the same chat.
+func (s *MessageService) SendMessage(ctx context.Context, chatID, senderID int, content string, replyToID int) (*ent.Message, error) {
+	create := s.client.Message.Create().
 		SetChatID(chatID).
 		SetSenderID(senderID).
-		SetContent(content).
-		Save(ctx)
+		SetContent(content)
+
+	if replyToID != 0 {
+		inChat, err := s.client.Message.Query().
+			Where(
+				message.ID(replyToID),
+				message.HasChatWith(chat.ID(chatID)),
+			).
+			Exist(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check replied message: %w", err)
+		}
+		if !inChat {
+			return nil, ErrMessageNotInChat
+		}
+		create.SetReplyToID(replyToID)
+	}
+
+	// Create message
+	newMessage, err := create.Save(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
+	s.publishMessage(ctx, event.MessageCreated, newMessage.ID)
+	if replyToID != 0 {
+		s.publishMessage(ctx, event.ThreadUpdated, replyToID)
+	}
+
 	return newMessage, nil
 }
 
@@ -36,6 +80,11 @@ func (s *MessageService) GetMessageByID(ctx context.Context, messageID int) (*en
 	msg, err := s.client.Message.Query().
 		Where(message.ID(messageID)).
 		WithSender().
+		WithChat().
+		WithReplyTo(func(q *ent.MessageQuery) {
+			q.WithSender()
+		}).
+		Modify(selectDeliveryCounts, selectThreadSummary).
 		Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -51,9 +100,14 @@ func (s *MessageService) ListChatMessages(ctx context.Context, chatID, limit, of
 	messages, err := s.client.Message.Query().
 		Where(message.HasChatWith(chat.ID(chatID))).
 		WithSender().
+		WithChat().
+		WithReplyTo(func(q *ent.MessageQuery) {
+			q.WithSender()
+		}).
 		Order(ent.Desc(message.FieldCreatedAt)).
 		Limit(limit).
 		Offset(offset).
+		Modify(selectDeliveryCounts, selectThreadSummary).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list messages: %w", err)
@@ -62,6 +116,27 @@ func (s *MessageService) ListChatMessages(ctx context.Context, chatID, limit, of
 	return messages, nil
 }
 
+// ListReplies returns the replies to a message, oldest first.
+func (s *MessageService) ListReplies(ctx context.Context, messageID, limit, offset int) ([]*ent.Message, error) {
+	replies, err := s.client.Message.Query().
+		Where(message.HasReplyToWith(message.ID(messageID))).
+		WithSender().
+		WithChat().
+		WithReplyTo(func(q *ent.MessageQuery) {
+			q.WithSender()
+		}).
+		Order(ent.Asc(message.FieldCreatedAt), ent.Asc(message.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		Modify(selectDeliveryCounts, selectThreadSummary).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replies: %w", err)
+	}
+
+	return replies, nil
+}
+
 func (s *MessageService) UpdateMessage(ctx context.Context, messageID int, content string) (*ent.Message, error) {
 	updatedMessage, err := s.client.Message.UpdateOneID(messageID).
 		SetContent(content).
@@ -73,11 +148,18 @@ func (s *MessageService) UpdateMessage(ctx context.Context, messageID int, conte
 		return nil, fmt.Errorf("failed to update message: %w", err)
 	}
 
+	s.publishMessage(ctx, event.MessageUpdated, messageID)
+
 	return updatedMessage, nil
 }
 
 func (s *MessageService) DeleteMessage(ctx context.Context, messageID int) error {
-	err := s.client.Message.DeleteOneID(messageID).Exec(ctx)
+	msg, err := s.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Message.DeleteOneID(messageID).Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return fmt.Errorf("message not found")
@@ -85,9 +167,40 @@ func (s *MessageService) DeleteMessage(ctx context.Context, messageID int) error
 		return fmt.Errorf("failed to delete message: %w", err)
 	}
 
+	s.publish(ctx, event.MessageDeleted, msg)
+	if msg.Edges.ReplyTo != nil {
+		s.publishMessage(ctx, event.ThreadUpdated, msg.Edges.ReplyTo.ID)
+	}
+
 	return nil
 }
 
+// ListReaders returns the members who read the message, most recent first.
+// The sender and users who hide read receipts are left out.
+func (s *MessageService) ListReaders(ctx context.Context, msg *ent.Message) ([]*ent.ChatMember, error) {
+	if msg.Edges.Chat == nil || msg.Edges.Sender == nil {
+		return nil, fmt.Errorf("message edges not loaded")
+	}
+
+	members, err := s.client.ChatMember.Query().
+		Where(
+			chatmember.HasChatWith(chat.ID(msg.Edges.Chat.ID)),
+			chatmember.LastReadMessageIDGTE(msg.ID),
+			chatmember.HasUserWith(
+				user.IDNEQ(msg.Edges.Sender.ID),
+				user.HideReadReceipts(false),
+			),
+		).
+		WithUser().
+		Order(ent.Desc(chatmember.FieldLastReadAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list readers: %w", err)
+	}
+
+	return members, nil
+}
+
 func (s *MessageService) GetMessageSender(ctx context.Context, messageID int) (*ent.User, error) {
 	msg, err := s.client.Message.Get(ctx, messageID)
 	if err != nil {
@@ -141,3 +254,255 @@ func (s *MessageService) IsUserSenderOfMessage(ctx context.Context, messageID, u
 
 	return exists, nil
 }
+
+// Receipt is a message that was written to a connection of a recipient.
+type Receipt struct {
+	MessageID int
+	UserID    int
+}
+
+// MarkDelivered records that messages reached recipients, and tells every
+// sender about the ones that had not before in one event.
+func (s *MessageService) MarkDelivered(ctx context.Context, receipts []Receipt) error {
+	ids := make([]int, 0, len(receipts))
+	for _, r := range receipts {
+		ids = append(ids, r.MessageID)
+	}
+	messages, err := s.client.Message.Query().
+		Where(message.IDIn(uniqueSorted(ids)...)).
+		WithSender().
+		WithChat().
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	byID := make(map[int]*ent.Message, len(messages))
+	for _, msg := range messages {
+		byID[msg.ID] = msg
+	}
+	pending := make([]pendingDelivery, 0, len(receipts))
+	for _, r := range receipts {
+		// Messages deleted meanwhile are skipped
+		if msg := byID[r.MessageID]; msg != nil {
+			pending = append(pending, pendingDelivery{message: msg, userID: r.UserID})
+		}
+	}
+
+	return s.recordDeliveries(ctx, pending)
+}
+
+// MarkFetched records that the messages of others a user fetched reached
+// them, and tells the senders about the ones that had not before. The
+// messages must have their sender and chat loaded.
+func (s *MessageService) MarkFetched(ctx context.Context, userID int, messages []*ent.Message) error {
+	pending := make([]pendingDelivery, 0, len(messages))
+	for _, msg := range messages {
+		pending = append(pending, pendingDelivery{message: msg, userID: userID})
+	}
+	return s.recordDeliveries(ctx, pending)
+}
+
+// pendingDelivery is a message, with its sender and chat loaded, that reached
+// a user.
+type pendingDelivery struct {
+	message *ent.Message
+	userID  int
+}
+
+type deliveryKey struct {
+	messageID int
+	userID    int
+}
+
+// recordDeliveries records the deliveries that were not recorded before and
+// publishes them with one message.delivered event per sender. Messages that
+// reach their own sender do not count.
+func (s *MessageService) recordDeliveries(ctx context.Context, pending []pendingDelivery) error {
+	deliveries := make(map[deliveryKey]*ent.Message, len(pending))
+	var messageIDs, userIDs []int
+	for _, d := range pending {
+		if d.message.Edges.Sender == nil || d.message.Edges.Chat == nil || d.message.Edges.Sender.ID == d.userID {
+			continue
+		}
+		deliveries[deliveryKey{messageID: d.message.ID, userID: d.userID}] = d.message
+		messageIDs = append(messageIDs, d.message.ID)
+		userIDs = append(userIDs, d.userID)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var recorded []struct {
+		MessageID int `sql:"message_id"`
+		UserID    int `sql:"user_id"`
+	}
+	err := s.client.MessageDelivery.Query().
+		Where(
+			messagedelivery.HasMessageWith(message.IDIn(uniqueSorted(messageIDs)...)),
+			messagedelivery.HasUserWith(user.IDIn(uniqueSorted(userIDs)...)),
+		).
+		Modify(func(sel *sql.Selector) {
+			sel.Select(
+				sql.As(sel.C(messagedelivery.MessageColumn), "message_id"),
+				sql.As(sel.C(messagedelivery.UserColumn), "user_id"),
+			)
+		}).
+		Scan(ctx, &recorded)
+	if err != nil {
+		return fmt.Errorf("failed to get deliveries: %w", err)
+	}
+	for _, r := range recorded {
+		delete(deliveries, deliveryKey{messageID: r.MessageID, userID: r.UserID})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	builders := make([]*ent.MessageDeliveryCreate, 0, len(deliveries))
+	bySender := make(map[int][]event.Delivery)
+	for key, msg := range deliveries {
+		builders = append(builders, s.client.MessageDelivery.Create().
+			SetMessageID(key.messageID).
+			SetUserID(key.userID).
+			SetDeliveredAt(now))
+
+		senderID := msg.Edges.Sender.ID
+		bySender[senderID] = append(bySender[senderID], event.Delivery{
+			MessageID:   key.messageID,
+			ChatID:      msg.Edges.Chat.ID,
+			UserID:      key.userID,
+			DeliveredAt: now,
+		})
+	}
+	// Another node may record some of them at the same time
+	err = s.client.MessageDelivery.CreateBulk(builders...).
+		OnConflict(sql.ConflictColumns(messagedelivery.MessageColumn, messagedelivery.UserColumn)).
+		DoNothing().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record deliveries: %w", err)
+	}
+
+	for senderID, batch := range bySender {
+		sort.Slice(batch, func(i, j int) bool {
+			if batch[i].MessageID != batch[j].MessageID {
+				return batch[i].MessageID < batch[j].MessageID
+			}
+			return batch[i].UserID < batch[j].UserID
+		})
+		s.events.Publish(ctx, event.Event{
+			Type:       event.MessageDelivered,
+			Recipients: []int{senderID},
+			Deliveries: batch,
+		})
+	}
+	return nil
+}
+
+// MessageDeliveryCounts returns the delivery counts selected with a message
+// by GetMessageByID and ListChatMessages.
+func MessageDeliveryCounts(msg *ent.Message) DeliveryCounts {
+	return DeliveryCounts{
+		Recipients: intValue(msg, "recipient_count"),
+		Delivered:  intValue(msg, "delivered_count"),
+		Read:       intValue(msg, "read_count"),
+	}
+}
+
+// MessageReplies returns the thread summary selected with a message by
+// GetMessageByID, ListChatMessages and ListReplies: how many replies it has
+// and when the last one was sent.
+func MessageReplies(msg *ent.Message) (count int, lastReplyAt *time.Time) {
+	return intValue(msg, "reply_count"), timeValue(msg, "last_reply_at")
+}
+
+// selectThreadSummary selects how many replies each message has as
+// reply_count and when the last one was sent as last_reply_at.
+func selectThreadSummary(sel *sql.Selector) {
+	replies := sql.Table(message.Table).As("replies")
+	isReply := func() *sql.Predicate {
+		return sql.ColumnsEQ(replies.C(message.ReplyToColumn), sel.C(message.FieldID))
+	}
+
+	sel.AppendSelectExprAs(sql.Dialect(sel.Dialect()).
+		Select(sql.Count("*")).
+		From(replies).
+		Where(isReply()), "reply_count").
+		AppendSelectExprAs(sql.Dialect(sel.Dialect()).
+			Select(sql.Max(replies.C(message.FieldCreatedAt))).
+			From(replies).
+			Where(isReply()), "last_reply_at")
+}
+
+// selectDeliveryCounts selects how many members other than the sender each
+// message has as recipient_count, how many of them received it as
+// delivered_count and how many visibly read it as read_count.
+func selectDeliveryCounts(sel *sql.Selector) {
+	members := sql.Table(chatmember.Table)
+	deliveries := sql.Table(messagedelivery.Table)
+	users := sql.Table(user.Table)
+
+	recipient := func() *sql.Predicate {
+		return sql.And(
+			sql.ColumnsEQ(members.C(chatmember.ChatColumn), sel.C(message.ChatColumn)),
+			sql.ColumnsNEQ(members.C(chatmember.UserColumn), sel.C(message.SenderColumn)),
+		)
+	}
+	read := func() *sql.Predicate {
+		return sql.ColumnsGTE(members.C(chatmember.FieldLastReadMessageID), sel.C(message.FieldID))
+	}
+	count := func(p *sql.Predicate) *sql.Selector {
+		return sql.Dialect(sel.Dialect()).
+			Select(sql.Count("*")).
+			From(members).
+			Where(p)
+	}
+
+	delivered := sql.Exists(sql.Dialect(sel.Dialect()).
+		Select().
+		From(deliveries).
+		Where(sql.And(
+			sql.ColumnsEQ(deliveries.C(messagedelivery.MessageColumn), sel.C(message.FieldID)),
+			sql.ColumnsEQ(deliveries.C(messagedelivery.UserColumn), members.C(chatmember.UserColumn)),
+		)))
+	hidesReceipts := sql.In(members.C(chatmember.UserColumn), sql.Dialect(sel.Dialect()).
+		Select(users.C(user.FieldID)).
+		From(users).
+		Where(sql.EQ(users.C(user.FieldHideReadReceipts), true)))
+
+	sel.AppendSelectExprAs(count(recipient()), "recipient_count").
+		AppendSelectExprAs(count(sql.And(recipient(), sql.Or(read(), delivered))), "delivered_count").
+		AppendSelectExprAs(count(sql.And(recipient(), read(), sql.Not(hidesReceipts))), "read_count")
+}
+
+// publishMessage publishes a message event to the members of its chat.
+func (s *MessageService) publishMessage(ctx context.Context, eventType string, messageID int) {
+	msg, err := s.GetMessageByID(ctx, messageID)
+	if err != nil {
+		log.Printf("Error publishing %s event of message %d: %v", eventType, messageID, err)
+		return
+	}
+	s.publish(ctx, eventType, msg)
+}
+
+func (s *MessageService) publish(ctx context.Context, eventType string, msg *ent.Message) {
+	if msg.Edges.Chat == nil {
+		return
+	}
+	chatID := msg.Edges.Chat.ID
+
+	recipients, err := chatMemberIDs(ctx, s.client, chatID)
+	if err != nil {
+		log.Printf("Error publishing %s event of message %d: %v", eventType, msg.ID, err)
+		return
+	}
+
+	s.events.Publish(ctx, event.Event{
+		Type:       eventType,
+		ChatID:     chatID,
+		Recipients: recipients,
+		Message:    msg,
+	})
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
+)
+
+func TestMarkDeliveredAggregatesPerSender(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	authService := newTestAuthService(t)
+	alice := createTestUser(t, client, authService, "alice", "")
+	bob := createTestUser(t, client, authService, "bob", "")
+	carol := createTestUser(t, client, authService, "carol", "")
+
+	chat, err := client.Chat.Create().SetName("team").SetIsGroup(true).SetCreator(alice).Save(ctx)
+	if err != nil {
+		t.Fatalf("create chat: %v", err)
+	}
+	first, err := client.Message.Create().SetChat(chat).SetSender(alice).SetContent("one").Save(ctx)
+	if err != nil {
+		t.Fatalf("create message: %v", err)
+	}
+	second, err := client.Message.Create().SetChat(chat).SetSender(alice).SetContent("two").Save(ctx)
+	if err != nil {
+		t.Fatalf("create message: %v", err)
+	}
+
+	events := event.NewBus()
+	var published []event.Event
+	events.Subscribe(func(_ context.Context, e event.Event) {
+		published = append(published, e)
+	})
+	s := NewMessageService(client, events)
+
+	receipts := []Receipt{
+		{MessageID: first.ID, UserID: bob.ID},
+		{MessageID: second.ID, UserID: bob.ID},
+		{MessageID: first.ID, UserID: carol.ID},
+		{MessageID: first.ID, UserID: bob.ID},   // another connection of bob
+		{MessageID: first.ID, UserID: alice.ID}, // the sender's own device
+		{MessageID: 999, UserID: bob.ID},        // deleted meanwhile
+	}
+	if err := s.MarkDelivered(ctx, receipts); err != nil {
+		t.Fatalf("mark delivered: %v", err)
+	}
+
+	if len(published) != 1 {
+		t.Fatalf("published %d events, want 1", len(published))
+	}
+	e := published[0]
+	if e.Type != event.MessageDelivered || len(e.Recipients) != 1 || e.Recipients[0] != alice.ID {
+		t.Fatalf("event = %+v, want message.delivered to alice", e)
+	}
+	if len(e.Deliveries) != 3 {
+		t.Fatalf("deliveries = %+v, want 3", e.Deliveries)
+	}
+
+	count, err := client.MessageDelivery.Query().Count(ctx)
+	if err != nil {
+		t.Fatalf("count deliveries: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("recorded %d deliveries, want 3", count)
+	}
+
+	// Deliveries recorded before are not announced again
+	published = nil
+	if err := s.MarkDelivered(ctx, receipts); err != nil {
+		t.Fatalf("mark delivered again: %v", err)
+	}
+	if len(published) != 0 {
+		t.Fatalf("published %d events for known deliveries, want 0", len(published))
+	}
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/predicate"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/recoverycode"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/usertoken"
+)
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication enrollment not started")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode    = errors.New("invalid two-factor code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired mfa token")
+	ErrInvalidPassword   = errors.New("invalid password")
+)
+
+// MFAEnrollment is returned when a user starts TOTP enrollment.
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type MFAService struct {
+	client             *ent.Client
+	authService        *auth.Service
+	totp               *auth.TOTP
+	clock              auth.Clock
+	pendingTokenTTL    time.Duration
+	maxAttempts        int
+	recoveryCodesCount int
+}
+
+func NewMFAService(client *ent.Client, authService *auth.Service, cfg config.MFAConfig, clock auth.Clock) *MFAService {
+	if clock == nil {
+		clock = auth.SystemClock
+	}
+	return &MFAService{
+		client:             client,
+		authService:        authService,
+		totp:               auth.NewTOTP(cfg.Issuer, clock),
+		clock:              clock,
+		pendingTokenTTL:    time.Duration(cfg.PendingTokenTTL) * time.Minute,
+		maxAttempts:        cfg.MaxAttempts,
+		recoveryCodesCount: cfg.RecoveryCodesCount,
+	}
+}
+
+// BeginEnrollment stores a new, not yet confirmed TOTP secret for the user.
+// Starting over replaces any previous unconfirmed secret.
+func (s *MFAService) BeginEnrollment(ctx context.Context, userID int) (*MFAEnrollment, error) {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.TotpEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.User.UpdateOneID(userID).
+		SetTotpSecret(secret).
+		ClearTotpLastStep().
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store totp secret: %w", err)
+	}
+
+	return &MFAEnrollment{
+		Secret: secret,
+		URI:    s.totp.URI(secret, u.Username),
+	}, nil
+}
+
+// ConfirmEnrollment enables TOTP once the user proves their authenticator
+// produces valid codes, and returns the first set of recovery codes.
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID int, code string) ([]string, error) {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.TotpEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if u.TotpSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	step, ok := s.totp.Validate(u.TotpSecret, code, u.TotpLastStep)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	err = tx.User.UpdateOneID(userID).
+		SetTotpEnabled(true).
+		SetTotpLastStep(step).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, tx, userID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Disable turns TOTP off after checking the password and a current code.
+func (s *MFAService) Disable(ctx context.Context, userID int, password, code string) error {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !u.TotpEnabled {
+		return ErrMFANotEnabled
+	}
+	if _, err := s.authService.VerifyPassword(u.Password, password); err != nil {
+		return ErrInvalidPassword
+	}
+	if err := s.VerifyCode(ctx, u, code); err != nil {
+		return err
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	err = tx.User.UpdateOneID(userID).
+		SetTotpEnabled(false).
+		ClearTotpSecret().
+		ClearTotpLastStep().
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to disable totp: %w", err)
+	}
+
+	_, err = tx.RecoveryCode.Delete().
+		Where(recoverycode.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes invalidates all existing recovery codes and issues
+// a new set. It requires a current TOTP code.
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error) {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !u.TotpEnabled {
+		return nil, ErrMFANotEnabled
+	}
+	if err := s.verifyTOTP(ctx, u, code); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, tx, userID)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return codes, nil
+}
+
+// VerifyCode accepts either a TOTP code or an unused recovery code.
+func (s *MFAService) VerifyCode(ctx context.Context, u *ent.User, code string) error {
+	if err := s.verifyTOTP(ctx, u, code); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrInvalidMFACode) {
+		return err
+	}
+
+	// Recovery codes are single use: only the request that flips used_at wins
+	affected, err := s.client.RecoveryCode.Update().
+		Where(
+			recoverycode.HasUserWith(user.ID(u.ID)),
+			recoverycode.CodeHash(auth.HashRecoveryCode(code)),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(s.clock.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if affected == 0 {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// CreatePendingToken issues the "MFA pending" token returned by the password
+// step. The token is recorded so it can be spent once and only takes a
+// limited number of codes.
+func (s *MFAService) CreatePendingToken(ctx context.Context, userID int) (string, time.Time, error) {
+	tokenID, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := s.clock.Now()
+	expiresAt := now.Add(s.pendingTokenTTL)
+
+	// Forget the expired pending tokens of the user on the way
+	_, err = s.client.UserToken.Delete().
+		Where(
+			usertoken.HasUserWith(user.ID(userID)),
+			usertoken.PurposeEQ(usertoken.PurposeMfaPending),
+			usertoken.ExpiresAtLTE(now),
+		).
+		Exec(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to delete expired mfa tokens: %w", err)
+	}
+
+	err = s.client.UserToken.Create().
+		SetUserID(userID).
+		SetPurpose(usertoken.PurposeMfaPending).
+		SetTokenHash(auth.HashOpaqueToken(tokenID)).
+		SetExpiresAt(expiresAt).
+		Exec(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to store mfa token: %w", err)
+	}
+
+	token, err := s.authService.CreateMFAToken(userID, tokenID, now, s.pendingTokenTTL)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create mfa token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// CompleteLogin exchanges an "MFA pending" token and a code for the user. A
+// token is spent by the first correct code and stops accepting codes after
+// the configured number of attempts.
+func (s *MFAService) CompleteLogin(ctx context.Context, mfaToken, code string) (*ent.User, error) {
+	now := s.clock.Now()
+	payload, err := s.authService.VerifyMFAToken(mfaToken, now)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	// Count the attempt before checking the code, so concurrent guesses
+	// cannot get past the limit
+	pending := []predicate.UserToken{
+		usertoken.TokenHash(auth.HashOpaqueToken(payload.TokenID)),
+		usertoken.PurposeEQ(usertoken.PurposeMfaPending),
+		usertoken.HasUserWith(user.ID(payload.UserID)),
+		usertoken.UsedAtIsNil(),
+		usertoken.ExpiresAtGT(now),
+	}
+	affected, err := s.client.UserToken.Update().
+		Where(append(pending, usertoken.AttemptsLT(s.maxAttempts))...).
+		AddAttempts(1).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count mfa attempt: %w", err)
+	}
+	if affected == 0 {
+		return nil, ErrInvalidMFAToken
+	}
+
+	u, err := s.client.User.Get(ctx, payload.UserID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !u.TotpEnabled {
+		return nil, ErrMFANotEnabled
+	}
+
+	if err := s.VerifyCode(ctx, u, code); err != nil {
+		return nil, err
+	}
+
+	// Only the request that flips used_at logs in
+	affected, err = s.client.UserToken.Update().
+		Where(pending...).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use mfa token: %w", err)
+	}
+	if affected == 0 {
+		return nil, ErrInvalidMFAToken
+	}
+
+	return u, nil
+}
+
+func (s *MFAService) verifyTOTP(ctx context.Context, u *ent.User, code string) error {
+	step, ok := s.totp.Validate(u.TotpSecret, code, u.TotpLastStep)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	// Only move the step forward, so concurrent requests cannot replay a code
+	affected, err := s.client.User.Update().
+		Where(
+			user.ID(u.ID),
+			user.Or(user.TotpLastStepIsNil(), user.TotpLastStepLT(step)),
+		).
+		SetTotpLastStep(step).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record totp step: %w", err)
+	}
+	if affected == 0 {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+func (s *MFAService) replaceRecoveryCodes(ctx context.Context, tx *ent.Tx, userID int) ([]string, error) {
+	codes, err := auth.GenerateRecoveryCodes(s.recoveryCodesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.RecoveryCode.Delete().
+		Where(recoverycode.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	builders := make([]*ent.RecoveryCodeCreate, len(codes))
+	for i, code := range codes {
+		builders[i] = tx.RecoveryCode.Create().
+			SetUserID(userID).
+			SetCodeHash(auth.HashRecoveryCode(code))
+	}
+	if _, err := tx.RecoveryCode.CreateBulk(builders...).Save(ctx); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
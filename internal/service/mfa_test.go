@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// newTestMFAService returns an MFA service on a fake clock and a user who
+// enrolled in TOTP.
+func newTestMFAService(t *testing.T) (*MFAService, *testClock, *ent.User) {
+	t.Helper()
+	ctx := context.Background()
+
+	client := newTestClient(t)
+	authService := newTestAuthService(t)
+	clock := &testClock{now: time.Now()}
+	s := NewMFAService(client, authService, config.DefaultConfig.Auth.MFA, clock)
+
+	u := createTestUser(t, client, authService, "alice", "correct horse battery")
+	enrollment, err := s.BeginEnrollment(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("begin enrollment: %v", err)
+	}
+	if _, err := s.ConfirmEnrollment(ctx, u.ID, nextCode(t, s, enrollment.Secret)); err != nil {
+		t.Fatalf("confirm enrollment: %v", err)
+	}
+	u, err = client.User.Get(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	return s, clock, u
+}
+
+// nextCode moves the clock to the next TOTP step and returns its code, since
+// every step is accepted once only.
+func nextCode(t *testing.T, s *MFAService, secret string) string {
+	t.Helper()
+	if c, ok := s.clock.(*testClock); ok {
+		c.now = c.now.Add(30 * time.Second)
+	}
+	code, err := s.totp.Code(secret, s.totp.Step())
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	return code
+}
+
+func TestCompleteLoginSpendsPendingToken(t *testing.T) {
+	ctx := context.Background()
+	s, _, u := newTestMFAService(t)
+
+	token, _, err := s.CreatePendingToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("create pending token: %v", err)
+	}
+
+	loggedIn, err := s.CompleteLogin(ctx, token, nextCode(t, s, u.TotpSecret))
+	if err != nil {
+		t.Fatalf("complete login: %v", err)
+	}
+	if loggedIn.ID != u.ID {
+		t.Fatalf("logged in as %d, want %d", loggedIn.ID, u.ID)
+	}
+
+	_, err = s.CompleteLogin(ctx, token, nextCode(t, s, u.TotpSecret))
+	if !errors.Is(err, ErrInvalidMFAToken) {
+		t.Fatalf("reusing the token: err = %v, want ErrInvalidMFAToken", err)
+	}
+}
+
+func TestCompleteLoginLimitsAttemptsPerToken(t *testing.T) {
+	ctx := context.Background()
+	s, _, u := newTestMFAService(t)
+
+	token, _, err := s.CreatePendingToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("create pending token: %v", err)
+	}
+
+	for i := 0; i < s.maxAttempts; i++ {
+		if _, err := s.CompleteLogin(ctx, token, "000000"); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("attempt %d: err = %v, want ErrInvalidMFACode", i+1, err)
+		}
+	}
+
+	// Even the right code is refused once the attempts are used up
+	_, err = s.CompleteLogin(ctx, token, nextCode(t, s, u.TotpSecret))
+	if !errors.Is(err, ErrInvalidMFAToken) {
+		t.Fatalf("after %d failures: err = %v, want ErrInvalidMFAToken", s.maxAttempts, err)
+	}
+
+	// A new login gets a fresh token
+	token, _, err = s.CreatePendingToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("create pending token: %v", err)
+	}
+	if _, err := s.CompleteLogin(ctx, token, nextCode(t, s, u.TotpSecret)); err != nil {
+		t.Fatalf("complete login with a new token: %v", err)
+	}
+}
+
+func TestCompleteLoginRejectsExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	s, clock, u := newTestMFAService(t)
+
+	token, expiresAt, err := s.CreatePendingToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("create pending token: %v", err)
+	}
+
+	clock.now = expiresAt.Add(time.Second)
+	code, err := s.totp.Code(u.TotpSecret, s.totp.Step())
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	if _, err := s.CompleteLogin(ctx, token, code); !errors.Is(err, ErrInvalidMFAToken) {
+		t.Fatalf("err = %v, want ErrInvalidMFAToken", err)
+	}
+}
@@ -0,0 +1,512 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/identity"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrUnknownProvider         = errors.New("unknown identity provider")
+	ErrInvalidOIDCState        = errors.New("invalid or expired login state")
+	ErrOIDCLoginFailed         = errors.New("identity provider login failed")
+	ErrIdentityNotLinked       = errors.New("no account is linked to this identity")
+	ErrIdentityLinkedElsewhere = errors.New("identity is already linked to another account")
+	ErrIdentityNotFound        = errors.New("identity not found")
+	ErrLastLoginMethod         = errors.New("cannot remove the only way to log in")
+)
+
+// oidcTimeout bounds every call to an identity provider.
+const oidcTimeout = 10 * time.Second
+
+// ssoPassword is stored as the password hash of users provisioned through
+// single sign-on, until they set a password with a reset link.
+const ssoPassword = auth.UnusablePasswordPrefix + "sso"
+
+var usernameDisallowed = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// OIDCProvider describes a configured identity provider.
+type OIDCProvider struct {
+	Name        string
+	DisplayName string
+}
+
+// OIDCLogin is the result of a completed OIDC flow.
+type OIDCLogin struct {
+	User        *ent.User
+	Provisioned bool // the user was created by this login
+	Linked      bool // the flow linked an identity instead of logging in
+	// AuthenticatedAt is when the user last authenticated at the provider,
+	// which can be long before this login if the provider kept them signed in
+	AuthenticatedAt time.Time
+}
+
+type oidcClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+	Name              string `json:"name"`
+	AuthTime          int64  `json:"auth_time"`
+}
+
+// oidcProvider is discovered on first use, so the server starts even if a
+// provider is temporarily unreachable.
+type oidcProvider struct {
+	cfg      config.OIDCProviderConfig
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, p.cfg.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover provider %q: %w", p.cfg.Name, err)
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range p.cfg.Scopes {
+		if scope != oidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+
+	return p.oauth, p.verifier, nil
+}
+
+// OIDCService implements single sign-on with the OpenID Connect
+// authorization-code flow and PKCE, and links external identities to users.
+type OIDCService struct {
+	client      *ent.Client
+	authService *auth.Service
+	clock       auth.Clock
+	stateTTL    time.Duration
+	providers   map[string]*oidcProvider
+	names       []string
+}
+
+func NewOIDCService(client *ent.Client, authService *auth.Service, cfg config.OIDCConfig, clock auth.Clock) (*OIDCService, error) {
+	if clock == nil {
+		clock = auth.SystemClock
+	}
+
+	s := &OIDCService{
+		client:      client,
+		authService: authService,
+		clock:       clock,
+		stateTTL:    time.Duration(cfg.StateTTL) * time.Minute,
+		providers:   make(map[string]*oidcProvider),
+	}
+
+	for _, p := range cfg.Providers {
+		if p.Name == "" || p.IssuerURL == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc provider %q needs name, issuer_url, client_id and redirect_url", p.Name)
+		}
+		if _, ok := s.providers[p.Name]; ok {
+			return nil, fmt.Errorf("duplicate oidc provider %q", p.Name)
+		}
+		s.providers[p.Name] = &oidcProvider{cfg: p}
+		s.names = append(s.names, p.Name)
+	}
+
+	return s, nil
+}
+
+// Providers returns the configured identity providers.
+func (s *OIDCService) Providers() []OIDCProvider {
+	providers := make([]OIDCProvider, 0, len(s.names))
+	for _, name := range s.names {
+		cfg := s.providers[name].cfg
+		displayName := cfg.DisplayName
+		if displayName == "" {
+			displayName = cfg.Name
+		}
+		providers = append(providers, OIDCProvider{Name: cfg.Name, DisplayName: displayName})
+	}
+	return providers
+}
+
+// StateTTL returns how long a started flow stays valid.
+func (s *OIDCService) StateTTL() time.Duration {
+	return s.stateTTL
+}
+
+// Begin starts a flow and returns the provider's authorization URL and the
+// sealed state, which the caller must keep on the client (e.g. in a cookie)
+// until the callback. Pass linkUserID to link an identity to that user
+// instead of logging in, and reauth to make the provider ask the user to log
+// in again even if they are still signed in there.
+func (s *OIDCService) Begin(ctx context.Context, providerName string, linkUserID int, reauth bool) (string, string, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oidcTimeout)
+	defer cancel()
+
+	oauthConfig, _, err := p.discover(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	stateValue, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	now := s.clock.Now()
+	state := auth.OIDCState{
+		Provider:     providerName,
+		State:        stateValue,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		LinkUserID:   linkUserID,
+		ExpireAt:     now.Add(s.stateTTL),
+	}
+
+	stateToken, err := s.authService.CreateOIDCStateToken(state, now)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create oidc state: %w", err)
+	}
+
+	opts := []oauth2.AuthCodeOption{
+		oidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(state.CodeVerifier),
+	}
+	if reauth {
+		opts = append(opts, oauth2.SetAuthURLParam("prompt", "login"), oauth2.SetAuthURLParam("max_age", "0"))
+	}
+	authURL := oauthConfig.AuthCodeURL(stateValue, opts...)
+
+	return authURL, stateToken, nil
+}
+
+// Complete finishes a flow: it checks the state, exchanges the code, verifies
+// the ID token and returns the user the identity belongs to, provisioning or
+// linking as needed.
+func (s *OIDCService) Complete(ctx context.Context, providerName, stateToken, stateValue, code string) (*OIDCLogin, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	state, err := s.authService.VerifyOIDCStateToken(stateToken, s.clock.Now())
+	if err != nil || state.Provider != providerName ||
+		subtle.ConstantTimeCompare([]byte(state.State), []byte(stateValue)) != 1 {
+		return nil, ErrInvalidOIDCState
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oidcTimeout)
+	defer cancel()
+
+	oauthConfig, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthToken, err := oauthConfig.Exchange(ctx, code, oauth2.VerifierOption(state.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to exchange code: %v", ErrOIDCLoginFailed, err)
+	}
+
+	rawIDToken, ok := oauthToken.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: no id_token in token response", ErrOIDCLoginFailed)
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid id_token: %v", ErrOIDCLoginFailed, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(state.Nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCLoginFailed)
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: invalid claims: %v", ErrOIDCLoginFailed, err)
+	}
+
+	// Use a fresh context, the provider calls may have used most of the timeout
+	if state.LinkUserID != 0 {
+		return s.link(context.Background(), state.LinkUserID, providerName, idToken.Subject, claims)
+	}
+	login, err := s.login(context.Background(), p.cfg, idToken.Subject, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Providers that do not send auth_time authenticated the user just now
+	login.AuthenticatedAt = s.clock.Now()
+	if claims.AuthTime > 0 {
+		login.AuthenticatedAt = time.Unix(claims.AuthTime, 0)
+	}
+	return login, nil
+}
+
+// ListIdentities returns the identities linked to the user.
+func (s *OIDCService) ListIdentities(ctx context.Context, userID int) ([]*ent.Identity, error) {
+	identities, err := s.client.Identity.Query().
+		Where(identity.HasUserWith(user.ID(userID))).
+		Order(ent.Asc(identity.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// Unlink removes an identity of the user, unless it is the only way left to
+// log in.
+func (s *OIDCService) Unlink(ctx context.Context, userID, identityID int) error {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	identities, err := s.ListIdentities(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, i := range identities {
+		if i.ID == identityID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrIdentityNotFound
+	}
+	if len(identities) == 1 && !auth.HasUsablePassword(u.Password) {
+		return ErrLastLoginMethod
+	}
+
+	if err := s.client.Identity.DeleteOneID(identityID).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+
+	return nil
+}
+
+func (s *OIDCService) login(ctx context.Context, cfg config.OIDCProviderConfig, subject string, claims oidcClaims) (*OIDCLogin, error) {
+	ident, err := s.findIdentity(ctx, cfg.Name, subject)
+	if err != nil {
+		return nil, err
+	}
+	if ident != nil {
+		return &OIDCLogin{User: ident.Edges.User}, s.touchIdentity(ctx, ident.ID, claims)
+	}
+
+	// Existing accounts are never matched by email: an account can only be
+	// linked by its logged in owner
+	if cfg.DisableAutoProvision {
+		return nil, ErrIdentityNotLinked
+	}
+
+	newUser, err := s.provision(ctx, cfg.Name, subject, claims)
+	if err != nil {
+		// A concurrent first login may have provisioned the identity already
+		if ent.IsConstraintError(err) {
+			if ident, _ := s.findIdentity(ctx, cfg.Name, subject); ident != nil {
+				return &OIDCLogin{User: ident.Edges.User}, nil
+			}
+		}
+		return nil, err
+	}
+
+	return &OIDCLogin{User: newUser, Provisioned: true}, nil
+}
+
+func (s *OIDCService) link(ctx context.Context, userID int, providerName, subject string, claims oidcClaims) (*OIDCLogin, error) {
+	ident, err := s.findIdentity(ctx, providerName, subject)
+	if err != nil {
+		return nil, err
+	}
+	if ident != nil {
+		if ident.Edges.User.ID != userID {
+			return nil, ErrIdentityLinkedElsewhere
+		}
+		return &OIDCLogin{User: ident.Edges.User, Linked: true}, s.touchIdentity(ctx, ident.ID, claims)
+	}
+
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	err = s.client.Identity.Create().
+		SetUserID(userID).
+		SetProvider(providerName).
+		SetSubject(subject).
+		SetEmail(truncate(claims.Email, 254)).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, ErrIdentityLinkedElsewhere
+		}
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return &OIDCLogin{User: u, Linked: true}, nil
+}
+
+func (s *OIDCService) findIdentity(ctx context.Context, providerName, subject string) (*ent.Identity, error) {
+	ident, err := s.client.Identity.Query().
+		Where(
+			identity.Provider(providerName),
+			identity.Subject(subject),
+		).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	return ident, nil
+}
+
+func (s *OIDCService) touchIdentity(ctx context.Context, identityID int, claims oidcClaims) error {
+	err := s.client.Identity.UpdateOneID(identityID).
+		SetEmail(truncate(claims.Email, 254)).
+		SetLastLoginAt(s.clock.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update identity: %w", err)
+	}
+	return nil
+}
+
+// provision creates a user for a new identity. The email is only taken over
+// if the provider verified it and no other account uses it.
+func (s *OIDCService) provision(ctx context.Context, providerName, subject string, claims oidcClaims) (*ent.User, error) {
+	username, err := s.availableUsername(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	create := tx.User.Create().
+		SetUsername(username).
+		SetPassword(ssoPassword).
+		SetDisplayName(truncate(claims.Name, 100))
+
+	email := NormalizeEmail(claims.Email)
+	if email != "" && claims.EmailVerified && len(email) <= 254 {
+		taken, err := tx.User.Query().Where(user.Email(email)).Exist(ctx)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("failed to check email existence: %w", err)
+		}
+		if !taken {
+			create.SetEmail(email).SetEmailVerifiedAt(s.clock.Now())
+		}
+	}
+
+	newUser, err := create.Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	err = tx.Identity.Create().
+		SetUser(newUser).
+		SetProvider(providerName).
+		SetSubject(subject).
+		SetEmail(truncate(claims.Email, 254)).
+		SetLastLoginAt(s.clock.Now()).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to create identity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return newUser, nil
+}
+
+// availableUsername derives a free username from the preferred username or
+// the email of the identity.
+func (s *OIDCService) availableUsername(ctx context.Context, claims oidcClaims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = usernameDisallowed.ReplaceAllString(strings.ToLower(base), "")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	for len(base) < 3 {
+		base += "_"
+	}
+
+	for i := 0; i < 20; i++ {
+		candidate := base
+		if i > 0 {
+			candidate = base + strconv.Itoa(i)
+		}
+
+		exists, err := s.client.User.Query().
+			Where(user.Username(candidate)).
+			Exist(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to check user existence: %w", err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+
+	suffix, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return base + "_" + usernameDisallowed.ReplaceAllString(strings.ToLower(suffix), "")[:6], nil
+}
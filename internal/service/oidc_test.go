@@ -0,0 +1,514 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/config"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/identity"
+	"github.com/go-jose/go-jose/v4"
+)
+
+const (
+	testClientID    = "chatapp"
+	testRedirectURL = "http://localhost/api/v1/auth/oidc/mock/callback"
+)
+
+// mockIssuer is a local OpenID Connect provider. Its authorization endpoint
+// logs in the identity in next without asking, and its token endpoint checks
+// the PKCE verifier like a real provider.
+type mockIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	next  mockIdentity
+	codes map[string]mockGrant
+}
+
+type mockIdentity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	// Nonce replaces the nonce of the authorization request when set
+	Nonce string
+	// AuthTime is sent as auth_time when set
+	AuthTime time.Time
+}
+
+type mockGrant struct {
+	identity  mockIdentity
+	nonce     string
+	challenge string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	m := &mockIssuer{key: key, codes: make(map[string]mockGrant)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("/jwks", m.jwks)
+	mux.HandleFunc("/authorize", m.authorize)
+	mux.HandleFunc("/token", m.token)
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+// loginAs sets the identity the next authorization logs in.
+func (m *mockIssuer) loginAs(identity mockIdentity) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.next = identity
+}
+
+func (m *mockIssuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                                m.URL,
+		"authorization_endpoint":                m.URL + "/authorize",
+		"token_endpoint":                        m.URL + "/token",
+		"jwks_uri":                              m.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (m *mockIssuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &m.key.PublicKey,
+		KeyID:     "mock",
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}}})
+}
+
+func (m *mockIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != testClientID || q.Get("redirect_uri") != testRedirectURL ||
+		q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	m.mu.Lock()
+	m.codes[code] = mockGrant{
+		identity:  m.next,
+		nonce:     q.Get("nonce"),
+		challenge: q.Get("code_challenge"),
+	}
+	m.mu.Unlock()
+
+	redirect, _ := url.Parse(q.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {code}, "state": {q.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	grant, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	m.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	nonce := grant.nonce
+	if grant.identity.Nonce != "" {
+		nonce = grant.identity.Nonce
+	}
+	now := time.Now()
+	idClaims := map[string]interface{}{
+		"iss":                m.URL,
+		"sub":                grant.identity.Subject,
+		"aud":                testClientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"nonce":              nonce,
+		"email":              grant.identity.Email,
+		"email_verified":     grant.identity.EmailVerified,
+		"preferred_username": grant.identity.PreferredUsername,
+	}
+	if !grant.identity.AuthTime.IsZero() {
+		idClaims["auth_time"] = grant.identity.AuthTime.Unix()
+	}
+	claims, _ := json.Marshal(idClaims)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: m.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "mock"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	signed, err := signer.Sign(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	idToken, _ := signed.CompactSerialize()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// oidcFlow is a flow started with Begin and authorized at the issuer.
+type oidcFlow struct {
+	authURL    *url.URL
+	stateToken string
+	state      string
+	code       string
+}
+
+func newTestOIDCService(t *testing.T, issuer *mockIssuer) (*OIDCService, *auth.Service) {
+	t.Helper()
+
+	authService := newTestAuthService(t)
+	s, err := NewOIDCService(newTestClient(t), authService, config.OIDCConfig{
+		StateTTL: 10,
+		Providers: []config.OIDCProviderConfig{{
+			Name:         "mock",
+			IssuerURL:    issuer.URL,
+			ClientID:     testClientID,
+			ClientSecret: "secret",
+			RedirectURL:  testRedirectURL,
+			Scopes:       []string{"openid", "email", "profile"},
+		}},
+	}, auth.SystemClock)
+	if err != nil {
+		t.Fatalf("create oidc service: %v", err)
+	}
+	return s, authService
+}
+
+// beginFlow starts a flow and follows the authorization URL to the issuer,
+// which redirects back with a code.
+func beginFlow(t *testing.T, s *OIDCService, linkUserID int) oidcFlow {
+	t.Helper()
+
+	authURL, stateToken, err := s.Begin(context.Background(), "mock", linkUserID, false)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: status %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	parsedAuthURL, _ := url.Parse(authURL)
+
+	return oidcFlow{
+		authURL:    parsedAuthURL,
+		stateToken: stateToken,
+		state:      location.Query().Get("state"),
+		code:       location.Query().Get("code"),
+	}
+}
+
+func TestOIDCLoginProvisionsUser(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, _ := newTestOIDCService(t, issuer)
+	ctx := context.Background()
+
+	issuer.loginAs(mockIdentity{
+		Subject:           "subject-1",
+		Email:             "Alice@Example.com",
+		EmailVerified:     true,
+		PreferredUsername: "Alice",
+	})
+	flow := beginFlow(t, s, 0)
+
+	q := flow.authURL.Query()
+	if q.Get("state") == "" || q.Get("nonce") == "" || q.Get("code_challenge") == "" {
+		t.Fatalf("authorization URL lacks state, nonce or PKCE challenge: %s", flow.authURL)
+	}
+
+	login, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if !login.Provisioned || login.Linked {
+		t.Fatalf("got provisioned=%v linked=%v, want a provisioned login", login.Provisioned, login.Linked)
+	}
+	if login.User.Username != "alice" {
+		t.Errorf("username = %q, want alice", login.User.Username)
+	}
+	if login.User.Email == nil || *login.User.Email != "alice@example.com" || login.User.EmailVerifiedAt == nil {
+		t.Errorf("email = %v verified at %v, want the verified provider email", login.User.Email, login.User.EmailVerifiedAt)
+	}
+	if auth.HasUsablePassword(login.User.Password) {
+		t.Error("provisioned user has a usable password")
+	}
+
+	// The next login finds the same user
+	flow = beginFlow(t, s, 0)
+	again, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("second complete: %v", err)
+	}
+	if again.Provisioned || again.User.ID != login.User.ID {
+		t.Errorf("second login got user %d provisioned=%v, want user %d", again.User.ID, again.Provisioned, login.User.ID)
+	}
+}
+
+func TestOIDCLoginReportsAuthTime(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, authService := newTestOIDCService(t, issuer)
+	ctx := context.Background()
+
+	// The provider kept the user signed in since an hour ago
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	issuer.loginAs(mockIdentity{Subject: "subject-1", PreferredUsername: "alice", AuthTime: authTime})
+	flow := beginFlow(t, s, 0)
+	login, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if !login.AuthenticatedAt.Equal(authTime) {
+		t.Fatalf("authenticated at %v, want %v", login.AuthenticatedAt, authTime)
+	}
+
+	// A session started from that login is not fresh enough for sensitive changes
+	sessions := NewSessionService(s.client, authService)
+	tokens, err := sessions.StartSession(ctx, login.User.ID, login.User.Username, SessionMetadata{AuthenticatedAt: login.AuthenticatedAt})
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	recent, err := sessions.RecentlyAuthenticated(ctx, tokens.SessionID, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("recently authenticated: %v", err)
+	}
+	if recent {
+		t.Fatal("session of an hour old provider login counts as recent")
+	}
+
+	// Logging in again at the provider makes it fresh
+	authURL, _, err := s.Begin(ctx, "mock", 0, true)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	parsed, _ := url.Parse(authURL)
+	if parsed.Query().Get("prompt") != "login" || parsed.Query().Get("max_age") != "0" {
+		t.Fatalf("reauth URL %s lacks prompt=login and max_age=0", authURL)
+	}
+	issuer.loginAs(mockIdentity{Subject: "subject-1", PreferredUsername: "alice", AuthTime: time.Now()})
+	flow = beginFlow(t, s, 0)
+	login, err = s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	tokens, err = sessions.StartSession(ctx, login.User.ID, login.User.Username, SessionMetadata{AuthenticatedAt: login.AuthenticatedAt})
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	if recent, err := sessions.RecentlyAuthenticated(ctx, tokens.SessionID, 5*time.Minute); err != nil || !recent {
+		t.Fatalf("recently authenticated = %v, %v, want true", recent, err)
+	}
+}
+
+func TestOIDCCompleteRejectsWrongState(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, _ := newTestOIDCService(t, issuer)
+	issuer.loginAs(mockIdentity{Subject: "subject-1", PreferredUsername: "alice"})
+
+	tests := []struct {
+		name   string
+		modify func(*oidcFlow)
+	}{
+		{"state parameter of another flow", func(f *oidcFlow) { f.state = "other" }},
+		{"tampered state token", func(f *oidcFlow) { f.stateToken += "x" }},
+		{"missing state token", func(f *oidcFlow) { f.stateToken = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow := beginFlow(t, s, 0)
+			tt.modify(&flow)
+
+			_, err := s.Complete(context.Background(), "mock", flow.stateToken, flow.state, flow.code)
+			if !errors.Is(err, ErrInvalidOIDCState) {
+				t.Fatalf("got %v, want ErrInvalidOIDCState", err)
+			}
+		})
+	}
+
+	flow := beginFlow(t, s, 0)
+	if _, err := s.Complete(context.Background(), "other", flow.stateToken, flow.state, flow.code); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("unknown provider: got %v, want ErrUnknownProvider", err)
+	}
+}
+
+func TestOIDCCompleteRejectsNonceMismatch(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, _ := newTestOIDCService(t, issuer)
+
+	issuer.loginAs(mockIdentity{Subject: "subject-1", PreferredUsername: "alice", Nonce: "replayed"})
+	flow := beginFlow(t, s, 0)
+
+	_, err := s.Complete(context.Background(), "mock", flow.stateToken, flow.state, flow.code)
+	if !errors.Is(err, ErrOIDCLoginFailed) {
+		t.Fatalf("got %v, want ErrOIDCLoginFailed", err)
+	}
+}
+
+func TestOIDCCompleteRejectsCodeOfAnotherFlow(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, _ := newTestOIDCService(t, issuer)
+	issuer.loginAs(mockIdentity{Subject: "subject-1", PreferredUsername: "alice"})
+
+	// A code issued for one flow cannot be redeemed with the PKCE verifier of
+	// another
+	stolen := beginFlow(t, s, 0)
+	own := beginFlow(t, s, 0)
+
+	_, err := s.Complete(context.Background(), "mock", own.stateToken, own.state, stolen.code)
+	if !errors.Is(err, ErrOIDCLoginFailed) {
+		t.Fatalf("got %v, want ErrOIDCLoginFailed", err)
+	}
+}
+
+func TestOIDCLinkIdentity(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, authService := newTestOIDCService(t, issuer)
+	ctx := context.Background()
+
+	alice := createTestUser(t, s.client, authService, "alice", "correct horse battery")
+	bob := createTestUser(t, s.client, authService, "bob", "correct horse battery")
+
+	issuer.loginAs(mockIdentity{Subject: "subject-1", Email: "alice@corp.example", EmailVerified: true})
+	flow := beginFlow(t, s, alice.ID)
+	login, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if !login.Linked || login.Provisioned || login.User.ID != alice.ID {
+		t.Fatalf("got user %d linked=%v provisioned=%v, want a link to user %d",
+			login.User.ID, login.Linked, login.Provisioned, alice.ID)
+	}
+
+	// Logging in with the identity now logs into the linked account
+	flow = beginFlow(t, s, 0)
+	login, err = s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if login.User.ID != alice.ID || login.Provisioned {
+		t.Fatalf("login got user %d provisioned=%v, want user %d", login.User.ID, login.Provisioned, alice.ID)
+	}
+
+	// Linking it again to the same user is a no-op, to another user fails
+	flow = beginFlow(t, s, alice.ID)
+	if _, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code); err != nil {
+		t.Fatalf("relink: %v", err)
+	}
+	flow = beginFlow(t, s, bob.ID)
+	if _, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code); !errors.Is(err, ErrIdentityLinkedElsewhere) {
+		t.Fatalf("link to another user: got %v, want ErrIdentityLinkedElsewhere", err)
+	}
+
+	count, err := s.client.Identity.Query().Where(identity.Subject("subject-1")).Count(ctx)
+	if err != nil || count != 1 {
+		t.Fatalf("identities = %d (%v), want 1", count, err)
+	}
+
+	// Alice still has a password, so the identity can be unlinked
+	identities, err := s.ListIdentities(ctx, alice.ID)
+	if err != nil || len(identities) != 1 {
+		t.Fatalf("list identities: %v %v", identities, err)
+	}
+	if err := s.Unlink(ctx, alice.ID, identities[0].ID); err != nil {
+		t.Fatalf("unlink: %v", err)
+	}
+}
+
+func TestOIDCUnlinkKeepsLastLoginMethod(t *testing.T) {
+	issuer := newMockIssuer(t)
+	s, _ := newTestOIDCService(t, issuer)
+	ctx := context.Background()
+
+	issuer.loginAs(mockIdentity{Subject: "subject-1", PreferredUsername: "alice"})
+	flow := beginFlow(t, s, 0)
+	login, err := s.Complete(ctx, "mock", flow.stateToken, flow.state, flow.code)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	identities, err := s.ListIdentities(ctx, login.User.ID)
+	if err != nil || len(identities) != 1 {
+		t.Fatalf("list identities: %v %v", identities, err)
+	}
+	if err := s.Unlink(ctx, login.User.ID, identities[0].ID); !errors.Is(err, ErrLastLoginMethod) {
+		t.Fatalf("got %v, want ErrLastLoginMethod", err)
+	}
+}
+
+func TestChangePasswordWithoutPasswordAfterSSO(t *testing.T) {
+	client := newTestClient(t)
+	authService := newTestAuthService(t)
+	s := NewUserService(client, authService)
+	ctx := context.Background()
+
+	u := createTestUser(t, client, authService, "alice", "")
+
+	// Without a password the caller must have re-authenticated the user
+	if err := s.ChangePassword(ctx, u.ID, "", "correct horse battery", false); !errors.Is(err, ErrReauthRequired) {
+		t.Fatalf("got %v, want ErrReauthRequired", err)
+	}
+	if err := s.ChangePassword(ctx, u.ID, "", "correct horse battery", true); err != nil {
+		t.Fatalf("set first password: %v", err)
+	}
+
+	// Once set, the password is required
+	if err := s.ChangePassword(ctx, u.ID, "", "another horse battery", true); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("got %v, want ErrInvalidPassword", err)
+	}
+	if err := s.ChangePassword(ctx, u.ID, "correct horse battery", "another horse battery", false); err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+}
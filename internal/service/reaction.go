@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/event"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chat"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/chatemoji"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/message"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/reaction"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/pkg/emoji"
+)
+
+var ErrInvalidEmoji = errors.New("not an emoji of the chat")
+
+// ReactionSummary is how many members reacted to a message with an emoji,
+// and whether the user asking is one of them.
+type ReactionSummary struct {
+	Emoji       string
+	Count       int
+	ReactedByMe bool
+}
+
+type ReactionService struct {
+	client *ent.Client
+	events *event.Bus
+}
+
+func NewReactionService(client *ent.Client, events *event.Bus) *ReactionService {
+	return &ReactionService{
+		client: client,
+		events: events,
+	}
+}
+
+// AddReaction reacts to a message with a Unicode emoji or a custom emoji of
+// its chat as :name:. Reacting twice with the same emoji does nothing. The
+// message must have its chat loaded.
+func (s *ReactionService) AddReaction(ctx context.Context, msg *ent.Message, userID int, value string) error {
+	if msg.Edges.Chat == nil {
+		return fmt.Errorf("message edges not loaded")
+	}
+	if err := s.validateEmoji(ctx, msg.Edges.Chat.ID, value); err != nil {
+		return err
+	}
+
+	err := s.client.Reaction.Create().
+		SetMessageID(msg.ID).
+		SetUserID(userID).
+		SetEmoji(value).
+		Exec(ctx)
+	if err != nil {
+		// Reacted before, or the message was deleted meanwhile
+		if ent.IsConstraintError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	s.publish(ctx, event.ReactionAdded, msg, userID, value)
+	return nil
+}
+
+// RemoveReaction takes back a reaction of a user. Removing a reaction that
+// does not exist does nothing. The message must have its chat loaded.
+func (s *ReactionService) RemoveReaction(ctx context.Context, msg *ent.Message, userID int, value string) error {
+	removed, err := s.client.Reaction.Delete().
+		Where(
+			reaction.HasMessageWith(message.ID(msg.ID)),
+			reaction.HasUserWith(user.ID(userID)),
+			reaction.Emoji(value),
+		).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	if removed > 0 {
+		s.publish(ctx, event.ReactionRemoved, msg, userID, value)
+	}
+	return nil
+}
+
+// ListReactions returns the reactions to a message with their users, oldest
+// first. A non-empty emoji only returns the reactions with it.
+func (s *ReactionService) ListReactions(ctx context.Context, messageID int, value string) ([]*ent.Reaction, error) {
+	query := s.client.Reaction.Query().
+		Where(reaction.HasMessageWith(message.ID(messageID)))
+	if value != "" {
+		query.Where(reaction.Emoji(value))
+	}
+
+	reactions, err := query.
+		WithUser().
+		Order(ent.Asc(reaction.FieldCreatedAt), ent.Asc(reaction.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+
+	return reactions, nil
+}
+
+// Summaries counts the reactions to each of the messages by emoji, in the
+// order the emoji were first used, and flags the ones of userID.
+func (s *ReactionService) Summaries(ctx context.Context, userID int, messageIDs []int) (map[int][]ReactionSummary, error) {
+	summaries := make(map[int][]ReactionSummary)
+	if len(messageIDs) == 0 {
+		return summaries, nil
+	}
+
+	var rows []struct {
+		MessageID int    `sql:"message_id"`
+		Emoji     string `sql:"emoji"`
+		Count     int    `sql:"count"`
+		Mine      int    `sql:"mine"`
+	}
+	err := s.client.Reaction.Query().
+		Where(reaction.HasMessageWith(message.IDIn(messageIDs...))).
+		Modify(func(sel *sql.Selector) {
+			messageColumn := sel.C(reaction.MessageColumn)
+			emojiColumn := sel.C(reaction.FieldEmoji)
+			mine := sql.ExprFunc(func(b *sql.Builder) {
+				b.WriteString("MAX(CASE WHEN ").
+					Ident(sel.C(reaction.UserColumn)).
+					WriteOp(sql.OpEQ).
+					Arg(userID).
+					WriteString(" THEN 1 ELSE 0 END)")
+			})
+
+			sel.Select(sql.As(messageColumn, "message_id"), emojiColumn, sql.As(sql.Count("*"), "count")).
+				AppendSelectExprAs(mine, "mine").
+				GroupBy(messageColumn, emojiColumn).
+				OrderBy(sql.Min(sel.C(reaction.FieldID)))
+		}).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	for _, row := range rows {
+		summaries[row.MessageID] = append(summaries[row.MessageID], ReactionSummary{
+			Emoji:       row.Emoji,
+			Count:       row.Count,
+			ReactedByMe: row.Mine > 0,
+		})
+	}
+	return summaries, nil
+}
+
+// validateEmoji accepts a single Unicode emoji, or :name: for a custom emoji
+// of the chat.
+func (s *ReactionService) validateEmoji(ctx context.Context, chatID int, value string) error {
+	if emoji.Is(value) {
+		return nil
+	}
+
+	name, ok := strings.CutPrefix(value, ":")
+	if ok {
+		name, ok = strings.CutSuffix(name, ":")
+	}
+	if !ok || name == "" {
+		return ErrInvalidEmoji
+	}
+
+	exists, err := s.client.ChatEmoji.Query().
+		Where(
+			chatemoji.HasChatWith(chat.ID(chatID)),
+			chatemoji.Name(name),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check emoji: %w", err)
+	}
+	if !exists {
+		return ErrInvalidEmoji
+	}
+	return nil
+}
+
+func (s *ReactionService) publish(ctx context.Context, eventType string, msg *ent.Message, userID int, value string) {
+	if msg.Edges.Chat == nil {
+		return
+	}
+	chatID := msg.Edges.Chat.ID
+
+	recipients, err := chatMemberIDs(ctx, s.client, chatID)
+	if err != nil {
+		log.Printf("Error publishing %s event of message %d: %v", eventType, msg.ID, err)
+		return
+	}
+
+	s.events.Publish(ctx, event.Event{
+		Type:       eventType,
+		ChatID:     chatID,
+		Recipients: recipients,
+		Message:    msg,
+		UserID:     userID,
+		Emoji:      value,
+	})
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/auth"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/predicate"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/session"
+	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrSessionRevoked      = errors.New("session revoked")
+	ErrSessionExpired      = errors.New("session expired")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// RevokeHook is called with the IDs of sessions right after they are revoked.
+type RevokeHook func(sessionIDs []int)
+
+// SessionMetadata describes the device a session was started from.
+type SessionMetadata struct {
+	DeviceName string
+	IPAddress  string
+	UserAgent  string
+	// AuthenticatedAt is when the user proved who they are, e.g. the
+	// auth_time of a single sign-on. Zero means now.
+	AuthenticatedAt time.Time
+}
+
+// TokenPair is the result of starting or refreshing a session.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	SessionID    int
+}
+
+type SessionService struct {
+	client      *ent.Client
+	authService *auth.Service
+	revokeHooks []RevokeHook
+}
+
+func NewSessionService(client *ent.Client, authService *auth.Service) *SessionService {
+	return &SessionService{
+		client:      client,
+		authService: authService,
+	}
+}
+
+// StartSession persists a new session for the user and issues its first token pair.
+func (s *SessionService) StartSession(ctx context.Context, userID int, username string, meta SessionMetadata) (*TokenPair, error) {
+	now := time.Now()
+	authenticatedAt := meta.AuthenticatedAt
+	if authenticatedAt.IsZero() || authenticatedAt.After(now) {
+		authenticatedAt = now
+	}
+
+	newSession, err := s.client.Session.Create().
+		SetUserID(userID).
+		SetDeviceName(truncate(meta.DeviceName, 100)).
+		SetIPAddress(truncate(meta.IPAddress, 64)).
+		SetUserAgent(truncate(meta.UserAgent, 512)).
+		SetExpiresAt(now.Add(s.authService.RefreshExpiration())).
+		SetLastUsedAt(now).
+		SetAuthenticatedAt(authenticatedAt).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return s.issueTokens(userID, username, newSession)
+}
+
+// Refresh rotates the refresh token of a session. Presenting a refresh token
+// from an older generation means it was stolen or replayed, so the whole
+// session is revoked.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string, meta SessionMetadata) (*TokenPair, error) {
+	payload, err := s.authService.VerifyRefreshToken(refreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	sess, err := s.client.Session.Query().
+		Where(session.ID(payload.SessionID)).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if sess.RevokedAt != nil {
+		return nil, ErrSessionRevoked
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		return nil, ErrSessionExpired
+	}
+	if payload.Generation != sess.RefreshGeneration {
+		return nil, s.handleReuse(ctx, sess.ID)
+	}
+
+	now := time.Now()
+	update := s.client.Session.Update().
+		Where(
+			session.ID(sess.ID),
+			session.RefreshGeneration(payload.Generation),
+			session.RevokedAtIsNil(),
+		).
+		AddRefreshGeneration(1).
+		SetLastUsedAt(now).
+		SetExpiresAt(now.Add(s.authService.RefreshExpiration()))
+	if meta.IPAddress != "" {
+		update.SetIPAddress(truncate(meta.IPAddress, 64))
+	}
+	if meta.UserAgent != "" {
+		update.SetUserAgent(truncate(meta.UserAgent, 512))
+	}
+
+	affected, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if affected == 0 {
+		// Another request rotated the same generation first.
+		return nil, s.handleReuse(ctx, sess.ID)
+	}
+
+	sess, err = s.client.Session.Query().
+		Where(session.ID(sess.ID)).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload session: %w", err)
+	}
+
+	return s.issueTokens(sess.Edges.User.ID, sess.Edges.User.Username, sess)
+}
+
+// IsSessionActive reports whether the session exists, has not expired and
+// has not been revoked.
+func (s *SessionService) IsSessionActive(ctx context.Context, sessionID int) (bool, error) {
+	active, err := s.client.Session.Query().
+		Where(
+			session.ID(sessionID),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+
+	return active, nil
+}
+
+// RecentlyAuthenticated reports whether the user logged in to the session
+// within the given window. Refreshing the tokens does not count.
+func (s *SessionService) RecentlyAuthenticated(ctx context.Context, sessionID int, window time.Duration) (bool, error) {
+	recent, err := s.client.Session.Query().
+		Where(
+			session.ID(sessionID),
+			session.RevokedAtIsNil(),
+			session.AuthenticatedAtGTE(time.Now().Add(-window)),
+		).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	return recent, nil
+}
+
+// OnRevoke registers a hook that is notified whenever sessions are revoked,
+// e.g. to close live connections authenticated with them. Hooks must be
+// registered before the service is used concurrently.
+func (s *SessionService) OnRevoke(hook RevokeHook) {
+	s.revokeHooks = append(s.revokeHooks, hook)
+}
+
+// ListUserSessions returns the active sessions of a user, most recently used first.
+func (s *SessionService) ListUserSessions(ctx context.Context, userID int) ([]*ent.Session, error) {
+	sessions, err := s.client.Session.Query().
+		Where(
+			session.HasUserWith(user.ID(userID)),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(session.FieldLastUsedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// UnrevokedSessionIDs returns the IDs of the sessions of a user that were not
+// revoked, expired ones included, since their connections may still be open.
+func (s *SessionService) UnrevokedSessionIDs(ctx context.Context, userID int) ([]int, error) {
+	ids, err := s.client.Session.Query().
+		Where(
+			session.HasUserWith(user.ID(userID)),
+			session.RevokedAtIsNil(),
+		).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	return ids, nil
+}
+
+// SessionsEnded notifies the revoke hooks about sessions that ended without
+// being revoked, e.g. because they were deleted with their user.
+func (s *SessionService) SessionsEnded(sessionIDs []int) {
+	if len(sessionIDs) == 0 {
+		return
+	}
+	for _, hook := range s.revokeHooks {
+		hook(sessionIDs)
+	}
+}
+
+// RevokeSession revokes a single session.
+func (s *SessionService) RevokeSession(ctx context.Context, sessionID int) error {
+	return s.revokeWhere(ctx, session.ID(sessionID))
+}
+
+// RevokeUserSession revokes a session after checking that it belongs to the user.
+func (s *SessionService) RevokeUserSession(ctx context.Context, userID, sessionID int) error {
+	exists, err := s.client.Session.Query().
+		Where(
+			session.ID(sessionID),
+			session.HasUserWith(user.ID(userID)),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if !exists {
+		return ErrSessionNotFound
+	}
+
+	return s.RevokeSession(ctx, sessionID)
+}
+
+// RevokeOtherSessions revokes every session of the user except keepSessionID.
+// Pass 0 to revoke all of them.
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID, keepSessionID int) error {
+	return s.revokeWhere(ctx,
+		session.HasUserWith(user.ID(userID)),
+		session.IDNEQ(keepSessionID),
+	)
+}
+
+func (s *SessionService) revokeWhere(ctx context.Context, ps ...predicate.Session) error {
+	ids, err := s.client.Session.Query().
+		Where(ps...).
+		Where(session.RevokedAtIsNil()).
+		IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = s.client.Session.Update().
+		Where(session.IDIn(ids...)).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.SessionsEnded(ids)
+	return nil
+}
+
+func (s *SessionService) handleReuse(ctx context.Context, sessionID int) error {
+	if err := s.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (s *SessionService) issueTokens(userID int, username string, sess *ent.Session) (*TokenPair, error) {
+	accessToken, err := s.authService.CreateToken(userID, username, sess.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	refreshToken, err := s.authService.CreateRefreshToken(sess.ID, sess.RefreshGeneration, sess.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(s.authService.AccessExpiration()),
+		SessionID:    sess.ID,
+	}, nil
+}
+
+// truncate shortens value to at most max bytes without splitting a UTF-8 sequence.
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	for max > 0 && !utf8.RuneStart(value[max]) {
+		max--
+	}
+	return value[:max]
+}
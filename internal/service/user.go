@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,10 @@ import (
 	"github.com/Hossara/quera_bootcamp_chatapp_backend/internal/repository/ent/user"
 )
 
+// ErrReauthRequired is returned when a user without a password has to prove
+// who they are again before a sensitive change.
+var ErrReauthRequired = errors.New("recent login required")
+
 type UserService struct {
 	client      *ent.Client
 	authService *auth.Service
@@ -48,6 +53,19 @@ func (s *UserService) GetUserByID(ctx context.Context, id int) (*ent.User, error
 	return u, nil
 }
 
+// GetUsersByIDs returns the existing users among the given IDs.
+func (s *UserService) GetUsersByIDs(ctx context.Context, ids []int) ([]*ent.User, error) {
+	users, err := s.client.User.Query().
+		Where(user.IDIn(ids...)).
+		Order(ent.Asc(user.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
 func (s *UserService) GetUserByUsername(ctx context.Context, username string) (*ent.User, error) {
 	u, err := s.client.User.Query().
 		Where(user.Username(username)).
@@ -73,19 +91,35 @@ func (s *UserService) UserExists(ctx context.Context, username string) (bool, er
 	return exists, nil
 }
 
-func (s *UserService) CreateUser(ctx context.Context, username, password, displayName string) (*ent.User, error) {
-	// Hash password
-	hashedPassword, err := s.authService.HashPassword(password)
+// EmailExists reports whether another account already uses the email address.
+func (s *UserService) EmailExists(ctx context.Context, email string) (bool, error) {
+	exists, err := s.client.User.Query().
+		Where(user.Email(NormalizeEmail(email))).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check email existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (s *UserService) CreateUser(ctx context.Context, username, password, displayName, email string) (*ent.User, error) {
+	// Check the password policy and hash password
+	hashedPassword, err := s.authService.HashNewPassword(password, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// Create user
-	newUser, err := s.client.User.Create().
+	create := s.client.User.Create().
 		SetUsername(username).
 		SetPassword(hashedPassword).
-		SetDisplayName(displayName).
-		Save(ctx)
+		SetDisplayName(displayName)
+	if email != "" {
+		create.SetEmail(NormalizeEmail(email))
+	}
+
+	newUser, err := create.Save(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -93,19 +127,34 @@ func (s *UserService) CreateUser(ctx context.Context, username, password, displa
 	return newUser, nil
 }
 
-func (s *UserService) UpdateUser(ctx context.Context, id int, displayName, password string) (*ent.User, error) {
+// UserUpdate holds the profile fields to change. Empty strings and nil
+// settings are left as they are.
+type UserUpdate struct {
+	DisplayName      string
+	Email            string
+	HideLastSeen     *bool
+	HideReadReceipts *bool
+}
+
+// UpdateUser updates the given fields. Changing the email address marks it
+// as unverified.
+func (s *UserService) UpdateUser(ctx context.Context, id int, changes UserUpdate) (*ent.User, error) {
 	update := s.client.User.UpdateOneID(id)
 
-	if displayName != "" {
-		update.SetDisplayName(displayName)
+	if changes.DisplayName != "" {
+		update.SetDisplayName(changes.DisplayName)
 	}
 
-	if password != "" {
-		hashedPassword, err := s.authService.HashPassword(password)
-		if err != nil {
-			return nil, fmt.Errorf("failed to hash password: %w", err)
-		}
-		update.SetPassword(hashedPassword)
+	if changes.HideLastSeen != nil {
+		update.SetHideLastSeen(*changes.HideLastSeen)
+	}
+
+	if changes.HideReadReceipts != nil {
+		update.SetHideReadReceipts(*changes.HideReadReceipts)
+	}
+
+	if changes.Email != "" {
+		update.SetEmail(NormalizeEmail(changes.Email)).ClearEmailVerifiedAt()
 	}
 
 	u, err := update.Save(ctx)
@@ -119,6 +168,40 @@ func (s *UserService) UpdateUser(ctx context.Context, id int, displayName, passw
 	return u, nil
 }
 
+// ChangePassword sets a new password after checking the current one. A wrong
+// current password returns ErrInvalidPassword. Accounts without a password,
+// e.g. after single sign-on, have nothing to check, so the caller must have
+// re-authenticated the user in another way; otherwise ErrReauthRequired is
+// returned.
+func (s *UserService) ChangePassword(ctx context.Context, id int, currentPassword, newPassword string, reauthenticated bool) error {
+	u, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if auth.HasUsablePassword(u.Password) {
+		if _, err := s.authService.VerifyPassword(u.Password, currentPassword); err != nil {
+			return ErrInvalidPassword
+		}
+	} else if !reauthenticated {
+		return ErrReauthRequired
+	}
+
+	hashedPassword, err := s.authService.HashNewPassword(newPassword, u.Username)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	err = s.client.User.UpdateOneID(id).
+		SetPassword(hashedPassword).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
 func (s *UserService) DeleteUser(ctx context.Context, id int) error {
 	err := s.client.User.DeleteOneID(id).Exec(ctx)
 	if err != nil {
@@ -131,10 +214,10 @@ func (s *UserService) DeleteUser(ctx context.Context, id int) error {
 	return nil
 }
 
-func (s *UserService) UpdateLastSeen(ctx context.Context, userID int) error {
-	now := time.Now()
+// UpdateLastSeen records when the user was last connected.
+func (s *UserService) UpdateLastSeen(ctx context.Context, userID int, at time.Time) error {
 	err := s.client.User.UpdateOneID(userID).
-		SetLastSeen(now).
+		SetLastSeen(at).
 		Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update last seen: %w", err)
@@ -143,15 +226,24 @@ func (s *UserService) UpdateLastSeen(ctx context.Context, userID int) error {
 	return nil
 }
 
-func (s *UserService) VerifyPassword(hashedPassword, password string) error {
+func (s *UserService) VerifyPassword(hashedPassword, password string) (needsRehash bool, err error) {
 	return s.authService.VerifyPassword(hashedPassword, password)
 }
 
-func (s *UserService) CreateToken(userID int, username string) (string, error) {
-	token, err := s.authService.CreateToken(userID, username)
+// UpgradePasswordHash replaces the stored hash of a just verified password
+// with one using the current algorithm and parameters.
+func (s *UserService) UpgradePasswordHash(ctx context.Context, id int, password string) error {
+	hashedPassword, err := s.authService.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	err = s.client.User.UpdateOneID(id).
+		SetPassword(hashedPassword).
+		Exec(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to create token: %w", err)
+		return fmt.Errorf("failed to update password hash: %w", err)
 	}
 
-	return token, nil
+	return nil
 }
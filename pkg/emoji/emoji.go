@@ -0,0 +1,234 @@
+// Package emoji recognizes Unicode emoji.
+package emoji
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zwj                = '\u200d'
+	presentationSelect = '\ufe0f'
+	keycapMark         = '\u20e3'
+	tagEnd             = '\U000E007F'
+)
+
+// pictographic holds the code points with the Emoji property (UTS #51,
+// Emoji 15.1), without the digits, '#' and '*' that only form keycaps and
+// without the regional indicators that only form flags.
+var pictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00A9, 0x00AE, 5},
+		{0x203C, 0x2049, 13},
+		{0x2122, 0x2139, 23},
+		{0x2194, 0x2199, 1},
+		{0x21A9, 0x21AA, 1},
+		{0x231A, 0x231B, 1},
+		{0x2328, 0x23CF, 167},
+		{0x23E9, 0x23F3, 1},
+		{0x23F8, 0x23FA, 1},
+		{0x24C2, 0x25AA, 232},
+		{0x25AB, 0x25B6, 11},
+		{0x25C0, 0x25FB, 59},
+		{0x25FC, 0x25FE, 1},
+		{0x2600, 0x2604, 1},
+		{0x260E, 0x2611, 3},
+		{0x2614, 0x2615, 1},
+		{0x2618, 0x261D, 5},
+		{0x2620, 0x2622, 2},
+		{0x2623, 0x2626, 3},
+		{0x262A, 0x262E, 4},
+		{0x262F, 0x2638, 9},
+		{0x2639, 0x263A, 1},
+		{0x2640, 0x2642, 2},
+		{0x2648, 0x2653, 1},
+		{0x265F, 0x2660, 1},
+		{0x2663, 0x2665, 2},
+		{0x2666, 0x2668, 2},
+		{0x267B, 0x267E, 3},
+		{0x267F, 0x2692, 19},
+		{0x2693, 0x2697, 1},
+		{0x2699, 0x269B, 2},
+		{0x269C, 0x26A0, 4},
+		{0x26A1, 0x26A7, 6},
+		{0x26AA, 0x26AB, 1},
+		{0x26B0, 0x26B1, 1},
+		{0x26BD, 0x26BE, 1},
+		{0x26C4, 0x26C5, 1},
+		{0x26C8, 0x26CE, 6},
+		{0x26CF, 0x26D1, 2},
+		{0x26D3, 0x26D4, 1},
+		{0x26E9, 0x26EA, 1},
+		{0x26F0, 0x26F5, 1},
+		{0x26F7, 0x26FA, 1},
+		{0x26FD, 0x2702, 5},
+		{0x2705, 0x2708, 3},
+		{0x2709, 0x270D, 1},
+		{0x270F, 0x2712, 3},
+		{0x2714, 0x2716, 2},
+		{0x271D, 0x2721, 4},
+		{0x2728, 0x2733, 11},
+		{0x2734, 0x2744, 16},
+		{0x2747, 0x274C, 5},
+		{0x274E, 0x2753, 5},
+		{0x2754, 0x2755, 1},
+		{0x2757, 0x2763, 12},
+		{0x2764, 0x2795, 49},
+		{0x2796, 0x2797, 1},
+		{0x27A1, 0x27B0, 15},
+		{0x27BF, 0x2934, 373},
+		{0x2935, 0x2B05, 464},
+		{0x2B06, 0x2B07, 1},
+		{0x2B1B, 0x2B1C, 1},
+		{0x2B50, 0x2B55, 5},
+		{0x3030, 0x303D, 13},
+		{0x3297, 0x3299, 2},
+	},
+	R32: []unicode.Range32{
+		{0x1F004, 0x1F0CF, 203},
+		{0x1F170, 0x1F171, 1},
+		{0x1F17E, 0x1F17F, 1},
+		{0x1F18E, 0x1F191, 3},
+		{0x1F192, 0x1F19A, 1},
+		{0x1F201, 0x1F202, 1},
+		{0x1F21A, 0x1F22F, 21},
+		{0x1F232, 0x1F23A, 1},
+		{0x1F250, 0x1F251, 1},
+		{0x1F300, 0x1F321, 1},
+		{0x1F324, 0x1F393, 1},
+		{0x1F396, 0x1F397, 1},
+		{0x1F399, 0x1F39B, 1},
+		{0x1F39E, 0x1F3F0, 1},
+		{0x1F3F3, 0x1F3F5, 1},
+		{0x1F3F7, 0x1F4FD, 1},
+		{0x1F4FF, 0x1F53D, 1},
+		{0x1F549, 0x1F54E, 1},
+		{0x1F550, 0x1F567, 1},
+		{0x1F56F, 0x1F570, 1},
+		{0x1F573, 0x1F57A, 1},
+		{0x1F587, 0x1F58A, 3},
+		{0x1F58B, 0x1F58D, 1},
+		{0x1F590, 0x1F595, 5},
+		{0x1F596, 0x1F5A4, 14},
+		{0x1F5A5, 0x1F5A8, 3},
+		{0x1F5B1, 0x1F5B2, 1},
+		{0x1F5BC, 0x1F5C2, 6},
+		{0x1F5C3, 0x1F5C4, 1},
+		{0x1F5D1, 0x1F5D3, 1},
+		{0x1F5DC, 0x1F5DE, 1},
+		{0x1F5E1, 0x1F5E3, 2},
+		{0x1F5E8, 0x1F5EF, 7},
+		{0x1F5F3, 0x1F5FA, 7},
+		{0x1F5FB, 0x1F64F, 1},
+		{0x1F680, 0x1F6C5, 1},
+		{0x1F6CB, 0x1F6D2, 1},
+		{0x1F6D5, 0x1F6D7, 1},
+		{0x1F6DC, 0x1F6E5, 1},
+		{0x1F6E9, 0x1F6EB, 2},
+		{0x1F6EC, 0x1F6F0, 4},
+		{0x1F6F3, 0x1F6FC, 1},
+		{0x1F7E0, 0x1F7EB, 1},
+		{0x1F7F0, 0x1F90C, 284},
+		{0x1F90D, 0x1F93A, 1},
+		{0x1F93C, 0x1F945, 1},
+		{0x1F947, 0x1F9FF, 1},
+		{0x1FA70, 0x1FA7C, 1},
+		{0x1FA80, 0x1FA88, 1},
+		{0x1FA90, 0x1FABD, 1},
+		{0x1FABF, 0x1FAC5, 1},
+		{0x1FACE, 0x1FADB, 1},
+		{0x1FAE0, 0x1FAE8, 1},
+		{0x1FAF0, 0x1FAF8, 1},
+	},
+	LatinOffset: 1,
+}
+
+// Is reports whether s is exactly one emoji as described by UTS #51: a
+// pictograph, optionally followed by the emoji presentation selector, a skin
+// tone or tags; a keycap; a flag; or a sequence of those joined with zero
+// width joiners, such as the woman technologist.
+func Is(s string) bool {
+	if s == "" {
+		return false
+	}
+	rest, ok := element(s)
+	for ok && rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+		if r != zwj {
+			return false
+		}
+		rest, ok = element(rest[size:])
+	}
+	return ok
+}
+
+// element consumes one emoji of a zero width joiner sequence from the start
+// of s and returns the rest.
+func element(s string) (string, bool) {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s, false
+	}
+	s = s[size:]
+
+	switch {
+	case isRegionalIndicator(r):
+		// A flag is a pair of regional indicators
+		next, size := utf8.DecodeRuneInString(s)
+		if !isRegionalIndicator(next) {
+			return s, false
+		}
+		return s[size:], true
+	case r == '#' || r == '*' || ('0' <= r && r <= '9'):
+		s = skip(s, presentationSelect)
+		next, size := utf8.DecodeRuneInString(s)
+		if next != keycapMark {
+			return s, false
+		}
+		return s[size:], true
+	case unicode.Is(pictographic, r):
+	default:
+		return s, false
+	}
+
+	s = skip(s, presentationSelect)
+	if next, size := utf8.DecodeRuneInString(s); isSkinTone(next) {
+		s = s[size:]
+	}
+
+	// Tag sequences, such as the flags of England or Scotland
+	if next, _ := utf8.DecodeRuneInString(s); isTag(next) {
+		for {
+			next, size := utf8.DecodeRuneInString(s)
+			switch {
+			case next == tagEnd:
+				return s[size:], true
+			case isTag(next):
+				s = s[size:]
+			default:
+				return s, false
+			}
+		}
+	}
+	return s, true
+}
+
+// skip drops r from the start of s, if it is there.
+func skip(s string, r rune) string {
+	if next, size := utf8.DecodeRuneInString(s); next == r {
+		return s[size:]
+	}
+	return s
+}
+
+func isRegionalIndicator(r rune) bool {
+	return 0x1F1E6 <= r && r <= 0x1F1FF
+}
+
+func isSkinTone(r rune) bool {
+	return 0x1F3FB <= r && r <= 0x1F3FF
+}
+
+func isTag(r rune) bool {
+	return 0xE0020 <= r && r <= 0xE007E
+}
@@ -0,0 +1,45 @@
+package emoji
+
+import "testing"
+
+func TestIs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"pictograph", "\U0001F600", true},
+		{"text style with selector", "\u2764\uFE0F", true},
+		{"text style without selector", "\u2764", true},
+		{"flag", "\U0001F1E9\U0001F1EA", true},
+		{"skin tone", "\U0001F44D\U0001F3FD", true},
+		{"keycap", "1\uFE0F\u20E3", true},
+		{"keycap without selector", "#\u20E3", true},
+		{"zwj sequence", "\U0001F469\u200D\U0001F4BB", true},
+		{"zwj sequence with skin tone", "\U0001F469\U0001F3FD\u200D\U0001F4BB", true},
+		{"family", "\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466", true},
+		{"tag sequence", "\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", true},
+
+		{"empty", "", false},
+		{"letter", "a", false},
+		{"digit", "1", false},
+		{"text", "hello", false},
+		{"two emoji", "\U0001F600\U0001F600", false},
+		{"emoji and text", "\U0001F600!", false},
+		{"single regional indicator", "\U0001F1E9", false},
+		{"three regional indicators", "\U0001F1E9\U0001F1EA\U0001F1EB", false},
+		{"keycap mark alone", "\u20E3", false},
+		{"trailing zwj", "\U0001F469\u200D", false},
+		{"leading zwj", "\u200D\U0001F4BB", false},
+		{"unterminated tag sequence", "\U0001F3F4\U000E0067\U000E0062", false},
+		{"invalid utf-8", "\xff", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.input); got != tt.want {
+				t.Errorf("Is(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}